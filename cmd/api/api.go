@@ -6,13 +6,19 @@ import (
 	"time"
 
 	"github.com/clemilsonazevedo/blog/config/database"
+	"github.com/clemilsonazevedo/blog/config/secret"
+	"github.com/clemilsonazevedo/blog/config/settings"
 	_ "github.com/clemilsonazevedo/blog/docs"
+	"github.com/clemilsonazevedo/blog/internal/authz"
 	"github.com/clemilsonazevedo/blog/internal/cache"
 	"github.com/clemilsonazevedo/blog/internal/controller"
 	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/http/auth"
 	"github.com/clemilsonazevedo/blog/internal/http/middlewares"
 	"github.com/clemilsonazevedo/blog/internal/http/routes/private"
 	"github.com/clemilsonazevedo/blog/internal/http/routes/public"
+	"github.com/clemilsonazevedo/blog/internal/mailer"
+	"github.com/clemilsonazevedo/blog/internal/oidc"
 	"github.com/clemilsonazevedo/blog/internal/repository"
 	"github.com/clemilsonazevedo/blog/internal/service"
 	"github.com/go-chi/chi/v5"
@@ -37,6 +43,12 @@ import (
 // @securityDefinitions.apikey CookieAuth
 // @in cookie
 // @name token
+// @description Access token set by /login in the token cookie. Ignored when the Authorization header is sent.
+
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Access token or API token (blog_...) as "Bearer <token>". Takes precedence over the token cookie.
 
 type User = entities.User
 type Post = entities.Post
@@ -44,6 +56,7 @@ type Comment = entities.Comment
 
 func InitServer() *chi.Mux {
 	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
 	r.Use(middleware.Logger)
 	r.Use(middleware.AllowContentType("application/json"))
 
@@ -52,22 +65,153 @@ func InitServer() *chi.Mux {
 		log.Fatal("ERROR INITIALIZING DATABASE")
 	}
 
+	if settings.GetAppEnv() != "development" && secret.IsDefaultJWTSecret() {
+		log.Fatal("ERROR: JWT_SECRET must be changed outside development")
+	}
+
+	var keyFiles []auth.KeyFile
+	for _, key := range settings.GetJWTSigningKeys() {
+		keyFiles = append(keyFiles, auth.KeyFile{ID: key.ID, Path: key.Path})
+	}
+	keyring, err := auth.LoadKeyring(keyFiles, settings.GetJWTActiveKey())
+	if err != nil {
+		log.Fatalf("ERROR LOADING JWT KEYS: %v", err)
+	}
+	auth.SetKeyring(keyring)
+
+	hasher, err := auth.NewPasswordHasher(settings.GetPasswordHashAlgorithm(), settings.GetPasswordHashCost(),
+		settings.GetArgon2Memory(), settings.GetArgon2Threads())
+	if err != nil {
+		log.Fatalf("ERROR CONFIGURING PASSWORD HASH: %v", err)
+	}
+	auth.SetPasswordHasher(hasher)
+
+	if policyFile := settings.GetAuthzPolicyFile(); policyFile != "" {
+		policy, err := authz.LoadPolicy(policyFile)
+		if err != nil {
+			log.Fatalf("ERROR LOADING PERMISSIONS: %v", err)
+		}
+		authz.SetPolicy(policy)
+	}
+
+	mail, err := mailer.New(mailer.Config{
+		Transport:    settings.GetMailTransport(),
+		From:         settings.GetMailFrom(),
+		SMTPHost:     settings.GetSMTPHost(),
+		SMTPPort:     settings.GetSMTPPort(),
+		SMTPUsername: settings.GetSMTPUsername(),
+		SMTPPassword: settings.GetSMTPPassword(),
+		LogFile:      settings.GetMailLogFile(),
+	})
+	if err != nil {
+		log.Fatalf("ERROR INITIALIZING MAILER: %v", err)
+	}
+
+	auditRepository := repository.NewAuditRepository(db)
+	auditService := service.NewAuditService(auditRepository)
+	if err := auditService.StartRetention(settings.GetAuditPruneInterval(), settings.GetAuditRetention()); err != nil {
+		log.Fatalf("ERROR STARTING AUDIT RETENTION: %v", err)
+	}
+	auditController := controller.NewAuditController(auditService)
+
 	userRepository := repository.NewUserRepository(db)
-	userService := service.NewUserService(userRepository)
-	userController := controller.NewUserController(userService)
+	userService := service.NewUserService(userRepository, mail, service.UserConfig{
+		AppURL:           settings.GetAppURL(),
+		VerificationTTL:  settings.GetEmailVerificationTTL(),
+		PasswordResetTTL: settings.GetPasswordResetTTL(),
+
+		TOTPIssuer:             settings.GetTOTPIssuer(),
+		ChallengeTTL:           settings.GetTwoFactorChallengeTTL(),
+		RequireAuthorTwoFactor: settings.GetRequireAuthorTwoFactor(),
+
+		PasswordPolicy: service.PasswordPolicy{
+			MinLength: settings.GetPasswordMinLength(),
+			MaxLength: settings.GetPasswordMaxLength(),
+		},
+	})
+
+	sessionRepository := repository.NewSessionRepository(db)
+	sessionService := service.NewSessionService(sessionRepository, userRepository, service.SessionConfig{
+		AccessTTL:  settings.GetAccessTokenTTL(),
+		RefreshTTL: settings.GetRefreshTokenTTL(),
+	})
+	loginGuard := service.NewLoginGuard(service.LoginGuardConfig{
+		FreeAttempts:       settings.GetLoginFreeAttempts(),
+		BaseDelay:          settings.GetLoginBaseDelay(),
+		MaxDelay:           settings.GetLoginMaxDelay(),
+		MaxAccountFailures: settings.GetLoginMaxAccountFailures(),
+		MaxIPFailures:      settings.GetLoginMaxIPFailures(),
+		LockoutDuration:    settings.GetLoginLockoutDuration(),
+		Window:             settings.GetLoginFailureWindow(),
+
+		MaxChallengeAttempts: settings.GetLoginMaxChallengeAttempts(),
+	})
+	userController := controller.NewUserController(userService, sessionService, auditService, loginGuard)
+
+	var providers []oidc.Config
+	for _, provider := range settings.GetOIDCProviders() {
+		providers = append(providers, oidc.Config{
+			Name:         provider.Name,
+			Issuer:       provider.Issuer,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			RedirectURL:  provider.RedirectURL,
+			Scopes:       provider.Scopes,
+		})
+	}
+	identityController := controller.NewIdentityController(oidc.NewRegistry(providers, nil), userService,
+		sessionService, auditService, settings.GetOIDCStateTTL())
+
+	adminController := controller.NewAdminController(userService, auditService)
+
+	apiTokenRepository := repository.NewApiTokenRepository(db)
+	apiTokenService := service.NewApiTokenService(apiTokenRepository, userRepository)
+	apiTokenController := controller.NewApiTokenController(apiTokenService, auditService)
+
+	tagRepository := repository.NewTagRepository(db)
+	tagService := service.NewTagService(tagRepository)
+	tagController := controller.NewTagController(tagService)
 
 	postRepository := repository.NewPostRepository(db)
 	postCache := cache.NewPostCache(5 * time.Minute)
-	postService := service.NewPostService(postRepository, postCache)
-	postController := controller.NewPostController(postService)
+	postService := service.NewPostService(postRepository, tagRepository, postCache)
+	postService.StartScheduler(time.Minute)
+	postController := controller.NewPostController(postService, auditService)
 
 	commentRepository := repository.NewCommentRepository(db)
-	commentService := service.NewCommentService(commentRepository)
-	commentController := controller.NewCommentController(commentService)
+	commentService := service.NewCommentService(commentRepository, service.CommentConfig{
+		MaxDepth:   settings.GetCommentMaxDepth(),
+		EditWindow: settings.GetCommentEditWindow(),
+
+		ModerationEnabled: settings.GetCommentModerationEnabled(),
+		AutoApproveAfter:  settings.GetCommentAutoApproveAfter(),
+	})
+	commentController := controller.NewCommentController(commentService, auditService)
+
+	var rateLimitStore service.RateLimitStore
+	switch settings.GetRateLimitStore() {
+	case "memory":
+		rateLimitStore = service.NewMemoryRateLimitStore()
+	case "postgres":
+		rateLimitStore = service.NewPostgresRateLimitStore(repository.NewRateLimitRepository(db))
+	default:
+		log.Fatal("ERROR: RATE_LIMIT_STORE must be memory or postgres")
+	}
+	rateLimits := middlewares.RateLimits{
+		Store:   rateLimitStore,
+		Auth:    rateLimitPolicy("auth", settings.GetRateLimitAuth()),
+		Read:    rateLimitPolicy("read", settings.GetRateLimitRead()),
+		Account: rateLimitPolicy("account", settings.GetRateLimitAccount()),
+		Write:   rateLimitPolicy("write", settings.GetRateLimitWrite()),
+		AI:      rateLimitPolicy("ai", settings.GetRateLimitAI()),
+	}
 
 	// Swagger UI route
 	r.Get("/swagger/*", httpSwagger.WrapHandler)
 
+	// Public keys of the access tokens
+	r.Get("/.well-known/jwks.json", controller.GetJWKS)
+
 	//api routes
 	r.Route("/api/v1", func(v1 chi.Router) {
 		v1.Use(middlewares.SetVersionHeader("v1.0"))
@@ -81,16 +225,36 @@ func InitServer() *chi.Mux {
 			userController,
 			postController,
 			commentController,
+			tagController,
+			identityController,
+			userService,
+			sessionService,
+			apiTokenService,
+			rateLimits,
 			v1,
 		)
 		private.BindPrivateRoutes(
 			postController,
 			userController,
 			commentController,
+			apiTokenController,
+			adminController,
+			auditController,
 			userService,
+			sessionService,
+			apiTokenService,
+			rateLimits,
 			v1,
 		)
 	})
 
 	return r
 }
+
+func rateLimitPolicy(name string, spec string) service.RateLimitPolicy {
+	policy, err := service.ParseRateLimitPolicy(name, spec)
+	if err != nil {
+		log.Fatalf("ERROR LOADING RATE LIMITS: %v", err)
+	}
+	return policy
+}
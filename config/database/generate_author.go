@@ -3,6 +3,7 @@ package database
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/clemilsonazevedo/blog/internal/domain/entities"
 	"github.com/clemilsonazevedo/blog/internal/domain/enums"
@@ -34,12 +35,14 @@ func CreateAuthor(db *gorm.DB) error {
 	}
 
 	authorId, err := pkg.NewULID()
+	verifiedAt := time.Now()
 	author := entities.User{
-		ID:       authorId,
-		UserName: authorName,
-		Email:    authorEmail,
-		Password: hashpassword,
-		Role:     enums.Author,
+		ID:              authorId,
+		UserName:        authorName,
+		Email:           authorEmail,
+		Password:        hashpassword,
+		Role:            enums.Author,
+		EmailVerifiedAt: &verifiedAt,
 	}
 
 	if err := db.Create(&author).Error; err != nil {
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
 	"gorm.io/gorm"
 )
 
@@ -20,12 +21,20 @@ func DropAll(db *gorm.DB) {
 }
 
 func MigrateRoleEnums(db *gorm.DB) error {
-	err := db.Exec("CREATE TYPE user_role AS ENUM ('anonymous', 'reader', 'author');").Error
+	err := db.Exec("CREATE TYPE user_role AS ENUM ('anonymous', 'reader', 'author', 'moderator', 'admin');").Error
 
 	if err != nil && !isTypeExistsError(err) {
 		return fmt.Errorf("failed to create enum type: %w", err)
 	}
 
+	// Databases created before a role existed only get the new value here
+	for _, role := range []string{"moderator", "admin"} {
+		err := db.Exec(fmt.Sprintf("ALTER TYPE user_role ADD VALUE IF NOT EXISTS '%s';", role)).Error
+		if err != nil {
+			return fmt.Errorf("failed to add role %s to enum type: %w", role, err)
+		}
+	}
+
 	fmt.Println("Enum type 'user_role' created or already exists")
 	return nil
 }
@@ -35,3 +44,46 @@ func isTypeExistsError(err error) bool {
 	return strings.Contains(errorStr, "type \"user_role\" already exists") ||
 		strings.Contains(errorStr, "SQLSTATE 42710")
 }
+
+// MigrateEmailVerification adds the email_verified_at column to an existing
+// users table. Accounts created before email confirmation existed are
+// considered verified, they would lose the right to comment otherwise.
+func MigrateEmailVerification(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&entities.User{}) || migrator.HasColumn(&entities.User{}, "EmailVerifiedAt") {
+		return nil
+	}
+
+	if err := migrator.AddColumn(&entities.User{}, "EmailVerifiedAt"); err != nil {
+		return fmt.Errorf("failed to add email verification column: %w", err)
+	}
+
+	if err := db.Exec("UPDATE users SET email_verified_at = created_at;").Error; err != nil {
+		return fmt.Errorf("failed to verify existing users: %w", err)
+	}
+
+	return nil
+}
+
+// MigratePostSearch adds the full text search column of the posts table.
+// GORM cannot declare generated columns, so it is kept out of entities.Post.
+// Titles weigh more than content and each post is indexed with the text
+// search configuration of its own language (pt-br or en).
+func MigratePostSearch(db *gorm.DB) error {
+	config := enums.SearchConfigSQL("language")
+	err := db.Exec(`ALTER TABLE posts ADD COLUMN IF NOT EXISTS search_vector tsvector
+		GENERATED ALWAYS AS (
+			setweight(to_tsvector(` + config + `, coalesce(title, '')), 'A') ||
+			setweight(to_tsvector(` + config + `, coalesce(content, '')), 'B')
+		) STORED;`).Error
+	if err != nil {
+		return fmt.Errorf("failed to create search column: %w", err)
+	}
+
+	err = db.Exec("CREATE INDEX IF NOT EXISTS idx_posts_search_vector ON posts USING GIN (search_vector);").Error
+	if err != nil {
+		return fmt.Errorf("failed to create search index: %w", err)
+	}
+
+	return nil
+}
@@ -27,12 +27,24 @@ func NewPostgresConfig() (*gorm.DB, error) {
 
 	log.Println("DATABASE CONNECTED")
 
+	if err := MigrateEmailVerification(db); err != nil {
+		log.Printf("It is not possible to migrate email verification: %v", err)
+	}
+
 	AutoMigrate(db)
 	MigrateRoleEnums(db)
 	AutoMigrate(db)
 
+	if err := MigratePostSearch(db); err != nil {
+		log.Printf("It is not possible to enable post search: %v", err)
+	}
+
 	if err := CreateAuthor(db); err != nil {
 		log.Printf("It is not possible to create the author: %v", err)
 	}
+
+	if err := PromoteAdmin(db); err != nil {
+		log.Printf("It is not possible to promote the admin: %v", err)
+	}
 	return db, nil
 }
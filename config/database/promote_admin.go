@@ -0,0 +1,46 @@
+package database
+
+import (
+	"log"
+	"os"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"gorm.io/gorm"
+)
+
+// PromoteAdmin makes the registered user with ADMIN_EMAIL an admin, the
+// first admin cannot be promoted through the API. The email must be
+// verified, otherwise anyone registering the address first would be admin.
+func PromoteAdmin(db *gorm.DB) error {
+	adminEmail := os.Getenv("ADMIN_EMAIL")
+	if adminEmail == "" {
+		return nil
+	}
+
+	result := db.
+		Model(&entities.User{}).
+		Where("email = ? AND role <> ? AND email_verified_at IS NOT NULL", adminEmail, enums.Admin).
+		Update("role", enums.Admin)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		log.Printf("Admin promoted: %s with success", adminEmail)
+		return nil
+	}
+
+	var unverified int64
+	err := db.
+		Model(&entities.User{}).
+		Where("email = ? AND email_verified_at IS NULL", adminEmail).
+		Count(&unverified).Error
+	if err != nil {
+		return err
+	}
+	if unverified > 0 {
+		log.Printf("WARNING: %s was not promoted to admin, its email is not verified", adminEmail)
+	}
+	return nil
+}
@@ -2,14 +2,22 @@ package secret
 
 import "os"
 
+const defaultJWTSecret = "in-production-change-this-please"
+
 func GetJWTSecret() string {
 	secret := os.Getenv("JWT_SECRET")
 	if secret == "" {
-		secret = "in-production-change-this-please"
+		secret = defaultJWTSecret
 	}
 	return secret
 }
 
+// IsDefaultJWTSecret reports whether JWT_SECRET is missing or still the
+// example value, anyone could then sign tokens.
+func IsDefaultJWTSecret() bool {
+	return GetJWTSecret() == defaultJWTSecret
+}
+
 func GetOpenAiKey() string {
 	api_key := os.Getenv("OPENAI_API_KEY")
 	if api_key == "" {
@@ -0,0 +1,181 @@
+package settings
+
+import (
+	"os"
+	"strings"
+	"time"
+)
+
+// GetAccessTokenTTL is how long an access token (the token cookie) is valid,
+// clients renew it with the refresh token.
+func GetAccessTokenTTL() time.Duration {
+	return getDuration("ACCESS_TOKEN_TTL", 15*time.Minute)
+}
+
+// GetRefreshTokenTTL is how long a session lasts without being used, every
+// refresh extends it.
+func GetRefreshTokenTTL() time.Duration {
+	return getDuration("REFRESH_TOKEN_TTL", 30*24*time.Hour)
+}
+
+// GetTOTPIssuer is the name of the blog in the authenticator apps.
+func GetTOTPIssuer() string {
+	return getString("TOTP_ISSUER", "Blog")
+}
+
+// GetTwoFactorChallengeTTL is how long a user has to type the two factor code
+// after the password.
+func GetTwoFactorChallengeTTL() time.Duration {
+	return getDuration("TWO_FACTOR_CHALLENGE_TTL", 5*time.Minute)
+}
+
+// GetRequireAuthorTwoFactor blocks the author routes until the author
+// enables two factor authentication.
+func GetRequireAuthorTwoFactor() bool {
+	return getBool("REQUIRE_AUTHOR_2FA", false)
+}
+
+// GetAuthzPolicyFile is a JSON permission matrix replacing the default one
+// of the authz package, empty keeps the default.
+func GetAuthzPolicyFile() string {
+	return getString("AUTHZ_POLICY_FILE", "")
+}
+
+// Failed logins: after LOGIN_FREE_ATTEMPTS failures each failure blocks for
+// LOGIN_BASE_DELAY, doubled every time up to LOGIN_MAX_DELAY. An account or
+// an IP reaching its maximum is locked for LOGIN_LOCKOUT_DURATION.
+func GetLoginFreeAttempts() int {
+	return getInt("LOGIN_FREE_ATTEMPTS", 3)
+}
+
+func GetLoginBaseDelay() time.Duration {
+	return getDuration("LOGIN_BASE_DELAY", time.Second)
+}
+
+func GetLoginMaxDelay() time.Duration {
+	return getDuration("LOGIN_MAX_DELAY", 5*time.Minute)
+}
+
+func GetLoginMaxAccountFailures() int {
+	return getInt("LOGIN_MAX_ACCOUNT_FAILURES", 10)
+}
+
+func GetLoginMaxIPFailures() int {
+	return getInt("LOGIN_MAX_IP_FAILURES", 50)
+}
+
+func GetLoginLockoutDuration() time.Duration {
+	return getDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute)
+}
+
+// GetLoginMaxChallengeAttempts is how many codes a two factor challenge
+// accepts before the password must be typed again.
+func GetLoginMaxChallengeAttempts() int {
+	return getInt("LOGIN_MAX_2FA_ATTEMPTS", 5)
+}
+
+// GetLoginFailureWindow is how long failures are remembered.
+func GetLoginFailureWindow() time.Duration {
+	return getDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute)
+}
+
+// GetAppEnv is development, staging, production... Outside development the
+// API refuses to start with the default JWT secret. An unset APP_ENV is
+// production, development must be chosen explicitly.
+func GetAppEnv() string {
+	return getString("APP_ENV", "production")
+}
+
+// JWTKeyFile is a PEM signing key of the access tokens and its kid.
+type JWTKeyFile struct {
+	ID   string
+	Path string
+}
+
+// GetJWTSigningKeys reads JWT_SIGNING_KEYS=kid=path,kid=path. Without keys
+// the access tokens are signed with JWT_SECRET (HS256).
+func GetJWTSigningKeys() []JWTKeyFile {
+	var keys []JWTKeyFile
+	for entry := range strings.SplitSeq(os.Getenv("JWT_SIGNING_KEYS"), ",") {
+		id, path, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || id == "" || path == "" {
+			continue
+		}
+		keys = append(keys, JWTKeyFile{ID: strings.TrimSpace(id), Path: strings.TrimSpace(path)})
+	}
+	return keys
+}
+
+// GetJWTActiveKey is the kid signing the new tokens, the first key when empty.
+// The other keys only verify, keep the previous key listed during a rotation.
+func GetJWTActiveKey() string {
+	return getString("JWT_ACTIVE_KEY", "")
+}
+
+// Password hashing: PASSWORD_HASH_ALGORITHM is bcrypt or argon2id.
+// PASSWORD_HASH_COST is the bcrypt cost or the argon2id iterations, zero
+// takes the default of the algorithm. Stored hashes weaker than this are
+// upgraded when the user logs in.
+func GetPasswordHashAlgorithm() string {
+	return getString("PASSWORD_HASH_ALGORITHM", "bcrypt")
+}
+
+func GetPasswordHashCost() int {
+	return getInt("PASSWORD_HASH_COST", 0)
+}
+
+// GetArgon2Memory is the argon2id memory in KiB.
+func GetArgon2Memory() int {
+	return getInt("ARGON2_MEMORY", 64*1024)
+}
+
+func GetArgon2Threads() int {
+	return getInt("ARGON2_THREADS", 2)
+}
+
+func GetPasswordMinLength() int {
+	return getInt("PASSWORD_MIN_LENGTH", 8)
+}
+
+// GetPasswordMaxLength is in bytes, bcrypt ignores what comes after 72.
+func GetPasswordMaxLength() int {
+	return getInt("PASSWORD_MAX_LENGTH", 72)
+}
+
+// GetAuditRetention is how long the audit events are kept.
+func GetAuditRetention() time.Duration {
+	return getDuration("AUDIT_RETENTION", 90*24*time.Hour)
+}
+
+// GetAuditPruneInterval is how often the old audit events are deleted, 0
+// keeps them forever.
+func GetAuditPruneInterval() time.Duration {
+	return getDuration("AUDIT_PRUNE_INTERVAL", 24*time.Hour)
+}
+
+// GetRateLimitStore is memory or postgres, postgres shares the limits
+// between the instances of the API.
+func GetRateLimitStore() string {
+	return getString("RATE_LIMIT_STORE", "memory")
+}
+
+// Rate limits are written limit/period, like 10/1m, "off" disables one.
+func GetRateLimitAuth() string {
+	return getString("RATE_LIMIT_AUTH", "10/1m")
+}
+
+func GetRateLimitRead() string {
+	return getString("RATE_LIMIT_READ", "300/1m")
+}
+
+func GetRateLimitAccount() string {
+	return getString("RATE_LIMIT_ACCOUNT", "120/1m")
+}
+
+func GetRateLimitWrite() string {
+	return getString("RATE_LIMIT_WRITE", "30/1m")
+}
+
+func GetRateLimitAI() string {
+	return getString("RATE_LIMIT_AI", "10/1h")
+}
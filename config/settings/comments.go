@@ -0,0 +1,28 @@
+package settings
+
+import "time"
+
+// GetCommentMaxDepth is how deep replies can be nested, 0 means only
+// top level comments (no replies at all).
+func GetCommentMaxDepth() int {
+	return max(getInt("COMMENT_MAX_DEPTH", 5), 0)
+}
+
+// GetCommentEditWindow is how long after posting the owner can still edit a
+// comment, 0 or a negative value means comments can always be edited.
+func GetCommentEditWindow() time.Duration {
+	return getDuration("COMMENT_EDIT_WINDOW", 15*time.Minute)
+}
+
+// GetCommentModerationEnabled puts every new comment in the moderation
+// queue, not only the ones on posts that require approval.
+func GetCommentModerationEnabled() bool {
+	return getBool("COMMENT_MODERATION", false)
+}
+
+// GetCommentAutoApproveAfter is how many comments of a user a moderator
+// must approve before new ones skip the moderation queue, 0 disables auto
+// approval.
+func GetCommentAutoApproveAfter() int {
+	return max(getInt("COMMENT_AUTO_APPROVE_AFTER", 3), 0)
+}
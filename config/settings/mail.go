@@ -0,0 +1,49 @@
+package settings
+
+import "time"
+
+// GetAppURL is the public address of the blog, used to build the links
+// sent by email.
+func GetAppURL() string {
+	return getString("APP_URL", "http://localhost:8080")
+}
+
+// GetEmailVerificationTTL is how long an email confirmation link is valid.
+func GetEmailVerificationTTL() time.Duration {
+	return getDuration("EMAIL_VERIFICATION_TTL", 24*time.Hour)
+}
+
+// GetPasswordResetTTL is how long a password reset link is valid.
+func GetPasswordResetTTL() time.Duration {
+	return getDuration("PASSWORD_RESET_TTL", time.Hour)
+}
+
+// GetMailTransport is how emails are delivered: smtp, log or memory.
+func GetMailTransport() string {
+	return getString("MAIL_TRANSPORT", "log")
+}
+
+func GetMailFrom() string {
+	return getString("MAIL_FROM", "Blog <no-reply@blog.io>")
+}
+
+// GetMailLogFile is where the log transport writes, empty means stdout.
+func GetMailLogFile() string {
+	return getString("MAIL_LOG_FILE", "")
+}
+
+func GetSMTPHost() string {
+	return getString("SMTP_HOST", "localhost")
+}
+
+func GetSMTPPort() int {
+	return getInt("SMTP_PORT", 587)
+}
+
+func GetSMTPUsername() string {
+	return getString("SMTP_USERNAME", "")
+}
+
+func GetSMTPPassword() string {
+	return getString("SMTP_PASSWORD", "")
+}
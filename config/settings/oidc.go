@@ -0,0 +1,54 @@
+package settings
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// OIDCProvider is an external login provider, configured with
+// OIDC_PROVIDERS=google,keycloak and for each name:
+// OIDC_GOOGLE_ISSUER, OIDC_GOOGLE_CLIENT_ID, OIDC_GOOGLE_CLIENT_SECRET and
+// optionally OIDC_GOOGLE_REDIRECT_URL and OIDC_GOOGLE_SCOPES.
+type OIDCProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scopes       []string
+}
+
+// GetOIDCProviders skips the providers without issuer or client id.
+func GetOIDCProviders() []OIDCProvider {
+	var providers []OIDCProvider
+	for name := range strings.SplitSeq(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		provider := OIDCProvider{
+			Name:         name,
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL: getString(prefix+"REDIRECT_URL",
+				fmt.Sprintf("%s/api/v1/auth/%s/callback", GetAppURL(), name)),
+			Scopes: strings.Fields(os.Getenv(prefix + "SCOPES")),
+		}
+		if provider.Issuer == "" || provider.ClientID == "" {
+			continue
+		}
+
+		providers = append(providers, provider)
+	}
+	return providers
+}
+
+// GetOIDCStateTTL is how long the user has to login on the provider.
+func GetOIDCStateTTL() time.Duration {
+	return getDuration("OIDC_STATE_TTL", 10*time.Minute)
+}
@@ -23,50 +23,84 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/comment": {
-            "post": {
+        "/admin/users": {
+            "get": {
                 "security": [
                     {
                         "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new comment on a post (authentication required)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists and searches the users (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Admin"
                 ],
-                "summary": "Create a new comment",
+                "summary": "List users",
                 "parameters": [
                     {
-                        "description": "Comment creation data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.CommentCreate"
-                        }
+                        "type": "string",
+                        "description": "Part of the username or email",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "anonymous",
+                            "reader",
+                            "author",
+                            "moderator",
+                            "admin"
+                        ],
+                        "type": "string",
+                        "description": "Only users with this role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "suspended",
+                            "banned"
+                        ],
+                        "type": "string",
+                        "description": "Only users with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of users per page (default: 10, max: 25)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Comment created",
+                    "200": {
+                        "description": "Returns data array and meta object with pagination info",
                         "schema": {
-                            "type": "string"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
-                        "description": "You need to provide all comments data",
+                        "description": "Invalid role or status",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Cannot create comment",
+                    "403": {
+                        "description": "Admin role required",
                         "schema": {
                             "type": "string"
                         }
@@ -74,14 +108,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/comment/{id}": {
-            "put": {
+        "/admin/users/{id}/ban": {
+            "post": {
                 "security": [
                     {
                         "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Updates an existing comment",
+                "description": "Blocks the login of a user for good and ends its sessions (admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -89,24 +126,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Admin"
                 ],
-                "summary": "Update a comment",
+                "summary": "Ban a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Comment ULID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Comment update data",
+                        "description": "Reason",
                         "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.CommentUpdate"
+                            "$ref": "#/definitions/request.UserBan"
                         }
                     }
                 ],
@@ -114,58 +150,66 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CommentResponse"
+                            "$ref": "#/definitions/response.AdminUserResponse"
                         }
                     },
-                    "400": {
-                        "description": "ID is required",
+                    "403": {
+                        "description": "Admin role required",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Error updating comment",
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/users/{id}/logout": {
+            "post": {
                 "security": [
                     {
                         "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Deletes an existing comment",
+                "description": "Ends every session of a user, its API tokens keep working (admin only)",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Comments"
+                    "Admin"
                 ],
-                "summary": "Delete a comment",
+                "summary": "Logout a user everywhere",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Comment ULID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/response.UserLogout"
                         }
                     },
-                    "400": {
-                        "description": "ID is required",
+                    "403": {
+                        "description": "Admin role required",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Error deleting comment",
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "type": "string"
                         }
@@ -173,25 +217,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/comments": {
-            "get": {
+        "/admin/users/{id}/reactivate": {
+            "post": {
                 "security": [
                     {
                         "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a single comment by its ULID (authentication required)",
+                "description": "Lifts the suspension or the ban of a user (admin only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Admin"
                 ],
-                "summary": "Get comment by ID",
+                "summary": "Reactivate a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Comment ULID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -201,17 +248,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.CommentResponse"
+                            "$ref": "#/definitions/response.AdminUserResponse"
                         }
                     },
-                    "400": {
-                        "description": "ID is required",
+                    "403": {
+                        "description": "Admin role required",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Error retrieving comment",
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "type": "string"
                         }
@@ -219,48 +266,66 @@ const docTemplate = `{
                 }
             }
         },
-        "/comments/user/{userID}": {
-            "get": {
+        "/admin/users/{id}/role": {
+            "put": {
                 "security": [
                     {
                         "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves all comments made by a specific user",
+                "description": "Promotes or demotes a user (admin only). Admins cannot change their own role.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Admin"
                 ],
-                "summary": "Get comments by user ID",
+                "summary": "Change the role of a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ULID",
-                        "name": "userID",
+                        "description": "User ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UserRoleChange"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.CommentResponse"
-                            }
+                            "$ref": "#/definitions/response.AdminUserResponse"
                         }
                     },
                     "400": {
-                        "description": "User ID is required",
+                        "description": "Invalid role",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Error retrieving comments",
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "type": "string"
                         }
@@ -268,43 +333,66 @@ const docTemplate = `{
                 }
             }
         },
-        "/comments/{postID}": {
-            "get": {
-                "description": "Retrieves all comments for a specific post",
+        "/admin/users/{id}/suspend": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Blocks the login of a user until a date and ends its sessions (admin only)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Comments"
+                    "Admin"
                 ],
-                "summary": "Get comments by post ID",
+                "summary": "Suspend a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Post ULID",
-                        "name": "postID",
+                        "description": "User ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "End of the suspension and reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UserSuspend"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/response.CommentResponse"
-                            }
+                            "$ref": "#/definitions/response.AdminUserResponse"
                         }
                     },
                     "400": {
-                        "description": "Post ID is required",
+                        "description": "The suspension must end in the future",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Error retrieving comments",
+                    "403": {
+                        "description": "Admin role required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "type": "string"
                         }
@@ -312,45 +400,89 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
-            "post": {
-                "description": "Authenticates a user and sets a JWT token in an HTTP-only cookie",
-                "consumes": [
-                    "application/json"
+        "/audit": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Lists the audit log newest first (admin only). Pass the next_cursor of a page as cursor to get the next one.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Admin"
                 ],
-                "summary": "Login user",
+                "summary": "List audit events",
                 "parameters": [
                     {
-                        "description": "User login credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.UserLogin"
-                        }
+                        "type": "string",
+                        "description": "Only events of this user",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events of this action, like user.role.change",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events on this kind of target, like post or user",
+                        "name": "target_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only events on this target",
+                        "name": "target_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Events created at or after this time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Events created before this time (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of events per page (default: 50, max: 200)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful - token set in cookie",
+                        "description": "OK",
                         "schema": {
-                            "type": "string"
-                        },
-                        "headers": {
-                            "Set-Cookie": {
-                                "type": "string",
-                                "description": "token=\u003cjwt\u003e; Path=/; HttpOnly; SameSite=Lax"
-                            }
+                            "$ref": "#/definitions/response.AuditEventsPage"
                         }
                     },
                     "400": {
-                        "description": "Email or Password is incorrect",
+                        "description": "Invalid filter or cursor",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin role required",
                         "schema": {
                             "type": "string"
                         }
@@ -358,75 +490,115 @@ const docTemplate = `{
                 }
             }
         },
-        "/logout": {
-            "post": {
-                "security": [
-                    {
-                        "CookieAuth": []
-                    }
-                ],
-                "description": "Clears the authentication cookie and logs out the user",
+        "/auth/providers": {
+            "get": {
+                "description": "Names of the external OpenID Connect providers, login with GET /auth/{provider}",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Logout user",
+                "summary": "List the login providers",
                 "responses": {
                     "200": {
-                        "description": "Logout successful",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.UserLogout"
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/post": {
-            "post": {
-                "security": [
-                    {
-                        "CookieAuth": []
-                    }
-                ],
-                "description": "Creates a new blog post (Author role required)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+        "/auth/{provider}": {
+            "get": {
+                "description": "Redirects to the OpenID Connect provider (authorization code flow with PKCE), the provider redirects back to /auth/{provider}/callback",
                 "tags": [
-                    "Posts"
+                    "Auth"
                 ],
-                "summary": "Create a new post",
+                "summary": "Login with an external provider",
                 "parameters": [
                     {
-                        "description": "Post creation data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.PostCreate"
-                        }
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Post created",
+                    "302": {
+                        "description": "Redirect to the provider",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "You need set Content and AuthorId to create a post",
+                    "404": {
+                        "description": "Unknown provider",
                         "schema": {
                             "type": "string"
                         }
-                    },
-                    "500": {
-                        "description": "Cannot create post",
+                    }
+                }
+            }
+        },
+        "/auth/{provider}/callback": {
+            "get": {
+                "description": "The provider redirects here after the login. The provider account is linked to the user with the same verified email, or a new user is created. Returns a two factor challenge when the user has two factor enabled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Complete an external login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State sent to the provider",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserLogin"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid state or login refused by the provider",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid ID token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Email used by another account",
                         "schema": {
                             "type": "string"
                         }
@@ -434,14 +606,17 @@ const docTemplate = `{
                 }
             }
         },
-        "/post-with-ai": {
+        "/comment": {
             "post": {
                 "security": [
                     {
                         "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new blog post with AI-generated title and hashtags from content (Author role required)",
+                "description": "Creates a new comment on a published post (authentication required)",
                 "consumes": [
                     "application/json"
                 ],
@@ -449,36 +624,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Posts",
-                    "AI"
+                    "Comments"
                 ],
-                "summary": "Create a post with AI-generated title and hashtags",
+                "summary": "Create a new comment",
                 "parameters": [
                     {
-                        "description": "AI post creation data",
+                        "description": "Comment creation data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.AiPostCreate"
+                            "$ref": "#/definitions/request.CommentCreate"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Post created",
+                        "description": "Comment created",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "You need set Content and AuthorId to create a post",
+                        "description": "You need to provide all comments data",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "This post does not exists",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Cannot create post",
+                        "description": "Cannot create comment",
                         "schema": {
                             "type": "string"
                         }
@@ -486,40 +666,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/post/slug/{slug}": {
-            "get": {
-                "description": "Retrieves a single post by its URL slug",
-                "produces": [
+        "/comments": {
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes a comment. The owner can always delete it; the post author and moderators can delete any comment on the post but must send a reason",
+                "consumes": [
                     "application/json"
                 ],
                 "tags": [
-                    "Posts"
+                    "Comments"
                 ],
-                "summary": "Get post by slug",
+                "summary": "Delete a comment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Post slug",
-                        "name": "slug",
-                        "in": "path",
+                        "description": "Comment ULID",
+                        "name": "commentId",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "description": "Moderation reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.CommentDelete"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Comment deleted",
                         "schema": {
-                            "$ref": "#/definitions/response.PostResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Slug is required",
+                        "description": "ID is required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "You cannot delete this comment",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment Does not exists",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Error retrieving post",
+                        "description": "Error deleting comment",
                         "schema": {
                             "type": "string"
                         }
@@ -527,53 +735,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/post/{id}": {
+        "/comments/pending": {
             "get": {
-                "description": "Retrieves a single post by its ULID",
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists comments waiting for approval on the posts of the authenticated author, or on every post for moderators",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Posts"
+                    "Comments"
                 ],
-                "summary": "Get post by ID",
+                "summary": "Get the comment moderation queue",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Post ULID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only comments of this post",
+                        "name": "postId",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.PostResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.CommentResponse"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Cannot parse Id of Post",
+                        "description": "Cannot Parse Post Id",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Error retrieving post",
+                        "description": "Error retrieving pending comments",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/comments/{id}": {
             "put": {
                 "security": [
                     {
                         "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Updates an existing blog post (Author role required)",
+                "description": "Edits the content of a comment. Only the owner can edit, within the configured edit window. On moderated posts an approved comment goes back to the moderation queue, like a new comment of its writer would, and its replies are hidden until it is approved again",
                 "consumes": [
                     "application/json"
                 ],
@@ -581,24 +804,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Posts"
+                    "Comments"
                 ],
-                "summary": "Update a post",
+                "summary": "Edit a comment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Post ULID",
+                        "description": "Comment ULID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Post update data",
+                        "description": "New comment content",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.PostUpdate"
+                            "$ref": "#/definitions/request.CommentUpdate"
                         }
                     }
                 ],
@@ -606,58 +829,95 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.PostResponse"
+                            "$ref": "#/definitions/response.CommentResponse"
                         }
                     },
                     "400": {
-                        "description": "ID is required",
+                        "description": "You need to provide the comment content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the owner can edit this comment",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment Does not exists",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Error updating post",
+                        "description": "Error editing comment",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/comments/{id}/approve": {
+            "post": {
                 "security": [
                     {
                         "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Deletes an existing blog post (Author role required)",
+                "description": "Publishes a comment waiting for moderation (post author or moderator)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Posts"
+                    "Comments"
                 ],
-                "summary": "Delete a post",
+                "summary": "Approve a pending comment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Post ULID",
+                        "description": "Comment ULID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Optional note",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.CommentReview"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content",
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.CommentResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "You cannot moderate this comment",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "ID is required",
+                    "404": {
+                        "description": "Comment Does not exists",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Error deleting post",
+                    "409": {
+                        "description": "Comment is not pending",
                         "schema": {
                             "type": "string"
                         }
@@ -665,21 +925,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/post/{postID}/comments": {
+        "/comments/{id}/edits": {
             "get": {
-                "description": "Retrieves all comments for a specific post",
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the previous contents of an edited comment, newest first. Only the author of the post and the moderators can read them",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Comments"
                 ],
-                "summary": "Get comments by post ID (alternative)",
+                "summary": "Get edit history of a comment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Post ULID",
-                        "name": "postID",
+                        "description": "Comment ULID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -690,18 +958,24 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/response.CommentResponse"
+                                "$ref": "#/definitions/response.CommentEditResponse"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Post ID is required",
+                    "403": {
+                        "description": "You cannot read the edits of this comment",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment Does not exists",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Error retrieving comments",
+                        "description": "Error retrieving edits",
                         "schema": {
                             "type": "string"
                         }
@@ -709,48 +983,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts": {
-            "get": {
-                "description": "Retrieves a paginated list of blog posts",
+        "/comments/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rejects a comment waiting for moderation so it is never shown (post author or moderator)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Posts"
+                    "Comments"
                 ],
-                "summary": "Get paginated posts",
+                "summary": "Reject a pending comment",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Comment ULID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Number of posts per page (default: 10, max: 100)",
-                        "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "description": "Rejection reason",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.CommentReview"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Returns data array and meta object with pagination info",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/response.CommentResponse"
                         }
                     },
-                    "400": {
-                        "description": "Limit is required",
+                    "403": {
+                        "description": "You cannot moderate this comment",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Error retrieving posts",
+                    "404": {
+                        "description": "Comment Does not exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Comment is not pending",
                         "schema": {
                             "type": "string"
                         }
@@ -758,28 +1049,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/posts/all": {
+        "/comments/{postID}": {
             "get": {
-                "description": "Retrieves all blog posts",
+                "description": "Retrieves all comments for a specific published post, as a flat list in reading order or as a tree of replies",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Posts"
+                    "Comments"
+                ],
+                "summary": "Get comments by post ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ULID",
+                        "name": "postID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "flat (default) or tree",
+                        "name": "view",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all posts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/entities.Post"
+                                "$ref": "#/definitions/response.CommentResponse"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Post ID is required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
-                        "description": "Error retrieving posts",
+                        "description": "Error retrieving comments",
                         "schema": {
                             "type": "string"
                         }
@@ -787,26 +1105,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/profile": {
+        "/drafts": {
             "get": {
                 "security": [
                     {
                         "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves the profile of the currently authenticated user",
+                "description": "Retrieves the draft and scheduled posts of the authenticated author (Author role required)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Posts"
                 ],
-                "summary": "Get current user profile",
+                "summary": "Get drafts of the current author",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.UserByID"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.PostResponse"
+                            }
                         }
                     },
                     "401": {
@@ -814,16 +1138,19 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    "500": {
+                        "description": "Error retrieving drafts",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 }
-            },
-            "put": {
-                "security": [
-                    {
-                        "CookieAuth": []
-                    }
-                ],
-                "description": "Updates the currently authenticated user's profile",
+            }
+        },
+        "/login": {
+            "post": {
+                "description": "Authenticates a user and starts a session: a short lived access token and a refresh token are set in HTTP-only cookies.\nWhen the account uses two factor authentication no session is started, a challenge token is returned for /login/2fa instead (response.TwoFactorChallenge).",
                 "consumes": [
                     "application/json"
                 ],
@@ -831,86 +1158,87 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Auth"
                 ],
-                "summary": "Update user profile",
+                "summary": "Login user",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ULID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "User update data",
+                        "description": "User login credentials",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UserUpdate"
+                            "$ref": "#/definitions/request.UserLogin"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Login successful - tokens set in cookies",
                         "schema": {
-                            "$ref": "#/definitions/response.UserByID"
+                            "$ref": "#/definitions/response.UserLogin"
+                        },
+                        "headers": {
+                            "Set-Cookie": {
+                                "type": "string",
+                                "description": "token=\u003cjwt\u003e; Path=/; HttpOnly; SameSite=Lax, refresh_token=\u003ctoken\u003e; Path=/api/v1/refresh; HttpOnly; SameSite=Lax"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Cannot Parse String to ULID",
+                        "description": "Email or Password is incorrect, the same answer whether the email exists or not",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Error updating user",
+                    "429": {
+                        "description": "Too many failed attempts, see the Retry-After header",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "CookieAuth": []
-                    }
+            }
+        },
+        "/login/2fa": {
+            "post": {
+                "description": "Completes the login of an account with two factor, with the challenge token returned by /login and a code of the app or a recovery code.\nEach challenge accepts LOGIN_MAX_2FA_ATTEMPTS codes (5 by default), wrong codes count as failed logins of the account.",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Deletes the currently authenticated user's account",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Auth"
                 ],
-                "summary": "Delete user profile",
+                "summary": "Login second step",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ULID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Challenge token and code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TwoFactorLogin"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.UserByID"
+                            "$ref": "#/definitions/response.UserLogin"
                         }
                     },
-                    "400": {
-                        "description": "ID is required",
+                    "401": {
+                        "description": "Too many wrong codes, login again",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Error deleting user",
+                    "429": {
+                        "description": "Too many failed attempts, see the Retry-After header",
                         "schema": {
                             "type": "string"
                         }
@@ -918,51 +1246,67 @@ const docTemplate = `{
                 }
             }
         },
-        "/register": {
+        "/logout": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes the current session and clears the authentication cookies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout user",
+                "responses": {
+                    "200": {
+                        "description": "Logout successful",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserLogout"
+                        }
+                    }
+                }
+            }
+        },
+        "/password/forgot": {
             "post": {
-                "description": "Creates a new user account with the provided credentials",
+                "description": "Emails a single use link to reset the password. The answer is always the same, registered or not, so it cannot be used to find which emails exist.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
                     "Auth"
                 ],
-                "summary": "Register a new user",
+                "summary": "Ask for a password reset",
                 "parameters": [
                     {
-                        "description": "User registration data",
+                        "description": "Account email",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UserRegister"
+                            "$ref": "#/definitions/request.PasswordForgot"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User Created has successfully",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/response.UserResponse"
                         }
                     },
                     "400": {
-                        "description": "Password need 8 or more characters",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "409": {
-                        "description": "User Already Exists",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "Cannot create user",
+                        "description": "Cannot Decode Body",
                         "schema": {
                             "type": "string"
                         }
@@ -970,45 +1314,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/user": {
-            "get": {
-                "security": [
-                    {
-                        "CookieAuth": []
-                    }
+        "/password/reset": {
+            "post": {
+                "description": "Sets a new password with the token sent by /password/forgot and logs the user out of every session",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Retrieves a user by their email address",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Auth"
                 ],
-                "summary": "Get user by email",
+                "summary": "Reset the password",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User email",
-                        "name": "email",
-                        "in": "query",
-                        "required": true
+                        "description": "Reset token and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PasswordReset"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.UserByID"
+                            "$ref": "#/definitions/response.UserResponse"
                         }
                     },
                     "400": {
-                        "description": "Email is required",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "500": {
-                        "description": "Error retrieving user",
+                        "description": "Password is too common",
                         "schema": {
                             "type": "string"
                         }
@@ -1016,45 +1354,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/name": {
-            "get": {
+        "/post": {
+            "post": {
                 "security": [
                     {
                         "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a user by their username",
+                "description": "Creates a new blog post (Author role required)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Posts"
                 ],
-                "summary": "Get user by name",
+                "summary": "Create a new post",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Username",
-                        "name": "name",
-                        "in": "query",
-                        "required": true
+                        "description": "Post creation data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PostCreate"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Post created",
                         "schema": {
-                            "$ref": "#/definitions/response.UserByID"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Name is required",
+                        "description": "You need set Content and AuthorId to create a post",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Error retrieving user",
+                        "description": "Cannot create post",
                         "schema": {
                             "type": "string"
                         }
@@ -1062,45 +1408,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/{id}": {
-            "get": {
+        "/post-with-ai": {
+            "post": {
                 "security": [
                     {
                         "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a user by their ULID",
+                "description": "Creates a new blog post with AI-generated title and hashtags from content (Author role required)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Posts",
+                    "AI"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Create a post with AI-generated title and hashtags",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ULID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "AI post creation data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.AiPostCreate"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Post created",
                         "schema": {
-                            "$ref": "#/definitions/response.UserByID"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "ID is required",
+                        "description": "You need set Content and AuthorId to create a post",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Error retrieving user",
+                        "description": "Cannot create post",
                         "schema": {
                             "type": "string"
                         }
@@ -1108,45 +1463,2510 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/post/{id}": {
             "get": {
+                "description": "Retrieves a single post by its ULID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Get post by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ULID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Cannot parse Id of Post",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error retrieving post",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
                 "security": [
                     {
                         "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a list of all users (Author role required)",
+                "description": "Updates an existing blog post (Author role required)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Posts"
+                ],
+                "summary": "Update a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ULID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Post update data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PostUpdate"
+                        }
+                    }
                 ],
-                "summary": "Get all users",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/entities.User"
-                            }
+                            "$ref": "#/definitions/response.PostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "ID is required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error updating post",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes an existing blog post (Author role required)",
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Delete a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ULID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "ID is required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error deleting post",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts": {
+            "get": {
+                "description": "Retrieves a paginated list of blog posts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Get paginated posts",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of posts per page (default: 10, max: 100)",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return posts with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns data array and meta object with pagination info",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Limit is required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error retrieving posts",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/archive": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes a post from the public listing without deleting it (Author role required)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Archive a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ULID",
+                        "name": "postId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PostResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Post Does not exists",
+                        "schema": {
+                            "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Error retrieving users",
+                        "description": "Error archiving post",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "entities.Post": {
+        },
+        "/posts/publish": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Publishes a post now, or schedules it when scheduled_for is a future date (Author role required)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Publish a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ULID",
+                        "name": "postId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional publication date",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.PostPublish"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid status for this Post",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Post Does not exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error publishing post",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/unpublish": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Moves a published or scheduled post back to draft (Author role required)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Unpublish a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ULID",
+                        "name": "postId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PostResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Post Does not exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error unpublishing post",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/comment-approval": {
+            "put": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turns the moderation queue on or off for a single post (post author or moderator)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Comments"
+                ],
+                "summary": "Require approval for comments of a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ULID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Moderation mode",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PostCommentApproval"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/request.PostCommentApproval"
+                        }
+                    },
+                    "403": {
+                        "description": "You cannot moderate this post",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Post Does not exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/reaction": {
+            "put": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets the reaction of the authenticated user to a post, replacing any previous one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Like or dislike a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ULID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reaction",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PostReaction"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PostReactionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Reaction must be like or dislike",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error saving reaction",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Removes the like or dislike of the authenticated user from a post",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Remove reaction from a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ULID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PostReactionResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error removing reaction",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves every saved revision of a post, newest first (Author role required)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Get revisions of a post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ULID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.PostRevisionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Cannot parse Id of Post",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error retrieving revisions",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/revisions/{rev}/diff": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a line based unified diff from the revision content to the current content (Author role required)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Diff a revision against the current post",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ULID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "rev",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PostRevisionDiffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Cannot parse revision number",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Revision not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error building diff",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{id}/revisions/{rev}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the post title and content with a revision, saving the current version as a new revision (Author role required)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Restore a revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post ULID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "rev",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PostResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Cannot parse revision number",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Revision not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error restoring revision",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/posts/{slug}": {
+            "get": {
+                "description": "Retrieves a single post by its slug, redirecting old slugs to the current one",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Get post by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Post slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.PostResponse"
+                        }
+                    },
+                    "301": {
+                        "description": "Moved to the current slug",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Post not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error retrieving post",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the profile of the currently authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get current user profile",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserProfile"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the currently authenticated user's profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ULID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User update data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UserUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Cannot Parse String to ULID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error updating user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the currently authenticated user's account",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete user profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ULID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserDeleted"
+                        }
+                    },
+                    "400": {
+                        "description": "ID is required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error deleting user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/profiles/2fa": {
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Disables two factor, it needs the password and a code (or a recovery code). Authors and admins cannot disable it when the blog requires it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Disable two factor authentication",
+                "parameters": [
+                    {
+                        "description": "Password and code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TwoFactorDisable"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserLogout"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid code",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Two factor is required for authors and admins",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/profiles/2fa/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Enables two factor with a code of the authenticator app and returns the recovery codes. They are only shown here, each works once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Enable two factor authentication",
+                "parameters": [
+                    {
+                        "description": "Code of the authenticator app",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.TwoFactorCode"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TwoFactorRecoveryCodes"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid code",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Two factor already enabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/profiles/2fa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a TOTP secret for the logged user. Add it to an authenticator app (the otpauth URI can be shown as a QR code) and confirm it with /profiles/2fa/confirm.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Start two factor enrollment",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.TwoFactorEnrollment"
+                        }
+                    },
+                    "409": {
+                        "description": "Two factor already enabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/profiles/email": {
+            "put": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sends a confirmation link to the new address. The email of the account only changes after it is confirmed in /profiles/email/confirm.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change the email",
+                "parameters": [
+                    {
+                        "description": "New email and current password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.EmailChange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "You need Provide a valid email",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Current password is incorrect",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already in use",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/profiles/email/confirm": {
+            "post": {
+                "description": "Switches the email of the account with the token sent by /profiles/email. Every session of the account is revoked, login again with the new email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Confirm the new email",
+                "parameters": [
+                    {
+                        "description": "Confirmation token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.EmailChangeConfirm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already in use",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/profiles/password": {
+            "put": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the password of the logged user. Every session is revoked and a new one is started for this client.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Change the password",
+                "parameters": [
+                    {
+                        "description": "Current and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.PasswordChange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserLogin"
+                        }
+                    },
+                    "400": {
+                        "description": "Password is too common",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Current password is incorrect",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/refresh": {
+            "post": {
+                "description": "Exchanges a refresh token (body or refresh_token cookie) for a new access token and a new refresh token. Each refresh token works once, using it again revokes the whole session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh the session",
+                "parameters": [
+                    {
+                        "description": "Refresh token, when not sent as cookie",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/request.SessionRefresh"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserLogin"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired refresh token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/register": {
+            "post": {
+                "description": "Creates a new user account with the provided credentials\nA confirmation link is sent to the email, only verified users can comment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User registration data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UserRegister"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User Created has successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Password is too common",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "User Already Exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Cannot create user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "Full text search over published posts, ranked by relevance with highlighted snippets. Snippets are escaped HTML where only the \u003cmark\u003e tags around the matches are markup",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Posts"
+                ],
+                "summary": "Search posts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search terms (supports quotes, OR and -exclusion)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of results per page (default: 10, max: 25)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns data array and meta object with pagination info",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Search query is required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error searching posts",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists where the logged user is logged in",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "List the active sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.SessionResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Logs the user out of one of its sessions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Revoke a session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ULID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserLogout"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Retrieves every tag with the number of posts using it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Get all tags",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.TagResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Error retrieving tags",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tokens": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the tokens of the logged user that were not revoked, secrets are never shown again",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "List the API tokens",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/response.ApiTokenResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a scoped token to use the API from scripts as \"Authorization: Bearer \u003ctoken\u003e\". The token is only shown in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Create an API token",
+                "parameters": [
+                    {
+                        "description": "Token name, scopes and optional expiry",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.ApiTokenCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/response.CreatedApiTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid scope or expiry",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tokens/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes a token of the logged user, it stops working right away",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tokens"
+                ],
+                "summary": "Revoke an API token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token ULID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserLogout"
+                        }
+                    },
+                    "404": {
+                        "description": "Token not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/user": {
+            "get": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a user by their email address",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user by email",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User email",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Email is required",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Error retrieving user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify-email": {
+            "post": {
+                "description": "Confirms the email of an account with the token sent by email. Each token can be used once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Confirm the user email",
+                "parameters": [
+                    {
+                        "description": "Verification token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.VerifyEmail"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserProfile"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already verified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify-email/resend": {
+            "post": {
+                "security": [
+                    {
+                        "CookieAuth": []
+                    },
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sends a new confirmation link to the email of the logged user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Resend the confirmation email",
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/response.UserResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already verified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "request.AiPostCreate": {
+            "type": "object",
+            "required": [
+                "author_id",
+                "content"
+            ],
+            "properties": {
+                "author_id": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "content": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "minLength": 2
+                },
+                "scheduled_for": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "scheduled",
+                        "published"
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "go",
+                        "backend"
+                    ]
+                }
+            }
+        },
+        "request.ApiTokenCreate": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "CI publisher"
+                },
+                "scopes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "posts:write"
+                    ]
+                }
+            }
+        },
+        "request.CommentCreate": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "parentId": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "postId": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                }
+            }
+        },
+        "request.CommentDelete": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Spam"
+                }
+            }
+        },
+        "request.CommentReview": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Off topic"
+                }
+            }
+        },
+        "request.CommentUpdate": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "postId": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                }
+            }
+        },
+        "request.EmailChange": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.EmailChangeConfirm": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.PasswordChange": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 8
+                }
+            }
+        },
+        "request.PasswordForgot": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.PasswordReset": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 8
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.PostCommentApproval": {
+            "type": "object",
+            "properties": {
+                "required": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "request.PostCreate": {
+            "type": "object",
+            "required": [
+                "author_id",
+                "content",
+                "title"
+            ],
+            "properties": {
+                "author_id": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "content": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "minLength": 2
+                },
+                "language": {
+                    "type": "string",
+                    "enum": [
+                        "pt-br",
+                        "en"
+                    ]
+                },
+                "scheduled_for": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "draft",
+                        "scheduled",
+                        "published"
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "go",
+                        "backend"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                }
+            }
+        },
+        "request.PostPublish": {
+            "type": "object",
+            "properties": {
+                "scheduled_for": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.PostReaction": {
+            "type": "object",
+            "required": [
+                "reaction"
+            ],
+            "properties": {
+                "reaction": {
+                    "type": "string",
+                    "enum": [
+                        "like",
+                        "dislike"
+                    ]
+                }
+            }
+        },
+        "request.PostUpdate": {
+            "type": "object",
+            "required": [
+                "author_id",
+                "content",
+                "title"
+            ],
+            "properties": {
+                "author_id": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "content": {
+                    "type": "string",
+                    "maxLength": 1000,
+                    "minLength": 2
+                },
+                "language": {
+                    "type": "string",
+                    "enum": [
+                        "pt-br",
+                        "en"
+                    ]
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "go",
+                        "backend"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                }
+            }
+        },
+        "request.SessionRefresh": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.TwoFactorCode": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "request.TwoFactorDisable": {
+            "type": "object",
+            "required": [
+                "code",
+                "password"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "123456"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "request.TwoFactorLogin": {
+            "type": "object",
+            "required": [
+                "challenge_token",
+                "code"
+            ],
+            "properties": {
+                "challenge_token": {
+                    "type": "string"
+                },
+                "code": {
+                    "description": "Code is a code of the authenticator app or a recovery code",
+                    "type": "string",
+                    "example": "123456"
+                }
+            }
+        },
+        "request.UserBan": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "request.UserLogin": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "device": {
+                    "description": "Device is an optional name to recognize the session, like \"Work laptop\"",
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 8
+                }
+            }
+        },
+        "request.UserRegister": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 8
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                }
+            }
+        },
+        "request.UserRoleChange": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "reader",
+                        "author",
+                        "moderator",
+                        "admin"
+                    ]
+                }
+            }
+        },
+        "request.UserSuspend": {
+            "type": "object",
+            "required": [
+                "until"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "until": {
+                    "type": "string",
+                    "example": "2026-01-01T00:00:00Z"
+                }
+            }
+        },
+        "request.UserUpdate": {
+            "type": "object",
+            "required": [
+                "id",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "minLength": 1,
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "anonymous",
+                        "reader",
+                        "author",
+                        "moderator",
+                        "admin"
+                    ]
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 2
+                }
+            }
+        },
+        "request.VerifyEmail": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AdminUserResponse": {
+            "type": "object",
+            "properties": {
+                "banned_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "email_verified": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "anonymous",
+                        "reader",
+                        "author",
+                        "moderator",
+                        "admin"
+                    ]
+                },
+                "status_reason": {
+                    "type": "string"
+                },
+                "suspended_until": {
+                    "type": "string"
+                },
+                "two_factor_enabled": {
+                    "type": "boolean"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ApiTokenResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "blog_k3v9x0qa"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "posts:write"
+                    ]
+                }
+            }
+        },
+        "response.AuditEventResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "example": "user.role.change"
+                },
+                "actor_id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "metadata": {
+                    "type": "object"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string"
+                },
+                "target_type": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.AuditEventsPage": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.AuditEventResponse"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CommentEditResponse": {
+            "type": "object",
+            "properties": {
+                "comment_id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "edited_by": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "previous_content": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.CommentResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "depth": {
+                    "type": "integer"
+                },
+                "edited_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "is_deleted": {
+                    "type": "boolean"
+                },
+                "parent_id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "post_id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "replies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/response.CommentResponse"
+                    }
+                },
+                "reply_count": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "approved",
+                        "rejected"
+                    ]
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                }
+            }
+        },
+        "response.CreatedApiTokenResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string",
+                    "example": "blog_k3v9x0qa"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "posts:write"
+                    ]
+                },
+                "token": {
+                    "type": "string",
+                    "example": "blog_k3v9x0qa_Xy3..."
+                }
+            }
+        },
+        "response.PostReactionResponse": {
+            "type": "object",
+            "properties": {
+                "dislikes": {
+                    "type": "integer"
+                },
+                "likes": {
+                    "type": "integer"
+                },
+                "post_id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "reaction": {
+                    "type": "string",
+                    "enum": [
+                        "like",
+                        "dislike"
+                    ]
+                }
+            }
+        },
+        "response.PostResponse": {
             "type": "object",
             "properties": {
+                "author_id": {
+                    "type": "string",
+                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
                 "content": {
                     "type": "string"
                 },
@@ -1160,321 +3980,215 @@ const docTemplate = `{
                     "type": "string",
                     "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
                 },
+                "language": {
+                    "type": "string",
+                    "enum": [
+                        "pt-br",
+                        "en"
+                    ]
+                },
                 "likes": {
                     "type": "integer"
                 },
-                "slug": {
+                "published_at": {
                     "type": "string"
                 },
-                "title": {
+                "scheduled_for": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/entities.User"
+                "slug": {
+                    "type": "string"
                 },
-                "user_id": {
+                "status": {
                     "type": "string",
-                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                    "enum": [
+                        "draft",
+                        "scheduled",
+                        "published",
+                        "archived"
+                    ]
                 },
-                "views": {
-                    "type": "integer"
-                }
-            }
-        },
-        "entities.User": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "string"
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "email": {
+                "title": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
-                },
-                "password": {
+                "updated_at": {
                     "type": "string"
                 },
-                "role": {
+                "user_reaction": {
                     "type": "string",
                     "enum": [
-                        "anonymous",
-                        "reader",
-                        "author"
+                        "like",
+                        "dislike"
                     ]
                 },
-                "username": {
-                    "type": "string"
-                }
-            }
-        },
-        "request.AiPostCreate": {
-            "type": "object",
-            "required": [
-                "content",
-                "userId"
-            ],
-            "properties": {
-                "content": {
-                    "type": "string",
-                    "maxLength": 1000,
-                    "minLength": 2
-                },
-                "userId": {
-                    "type": "string",
-                    "minLength": 1,
-                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                "views": {
+                    "type": "integer"
                 }
             }
         },
-        "request.CommentCreate": {
+        "response.PostRevisionDiffResponse": {
             "type": "object",
             "properties": {
-                "content": {
+                "diff": {
                     "type": "string"
                 },
-                "postId": {
+                "post_id": {
                     "type": "string",
                     "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
                 },
-                "userId": {
-                    "type": "string",
-                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                "revision": {
+                    "type": "integer"
                 }
             }
         },
-        "request.CommentUpdate": {
+        "response.PostRevisionResponse": {
             "type": "object",
             "properties": {
                 "content": {
                     "type": "string"
                 },
+                "created_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string",
                     "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
                 },
-                "postId": {
+                "post_id": {
                     "type": "string",
                     "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
                 },
-                "userId": {
-                    "type": "string",
-                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                "revision": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "request.PostCreate": {
+        "response.SessionResponse": {
             "type": "object",
-            "required": [
-                "content",
-                "dislikes",
-                "likes",
-                "title",
-                "userId"
-            ],
             "properties": {
-                "content": {
-                    "type": "string",
-                    "maxLength": 1000,
-                    "minLength": 2
+                "current": {
+                    "type": "boolean"
                 },
-                "dislikes": {
-                    "type": "integer",
-                    "minimum": 0
-                },
-                "likes": {
-                    "type": "integer",
-                    "minimum": 0
+                "device": {
+                    "type": "string"
                 },
-                "title": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                "expires_at": {
+                    "type": "string"
                 },
-                "userId": {
+                "id": {
                     "type": "string",
-                    "minLength": 1,
                     "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "signed_in_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
                 }
             }
         },
-        "request.PostUpdate": {
+        "response.TagResponse": {
             "type": "object",
-            "required": [
-                "content",
-                "dislikes",
-                "id",
-                "likes",
-                "title"
-            ],
             "properties": {
-                "content": {
-                    "type": "string",
-                    "maxLength": 1000,
-                    "minLength": 2
-                },
-                "dislikes": {
-                    "type": "integer",
-                    "minimum": 0
-                },
                 "id": {
                     "type": "string",
-                    "minLength": 1,
                     "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
                 },
-                "likes": {
-                    "type": "integer",
-                    "minimum": 0
+                "name": {
+                    "type": "string"
                 },
-                "title": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
+                "post_count": {
+                    "type": "integer"
+                },
+                "slug": {
+                    "type": "string"
                 }
             }
         },
-        "request.UserLogin": {
+        "response.TwoFactorEnrollment": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
+                "otpauth_uri": {
+                    "type": "string",
+                    "example": "otpauth://totp/Blog:user@blog.io?secret=JBSWY3DPEHPK3PXP\u0026issuer=Blog"
                 },
-                "password": {
+                "secret": {
                     "type": "string",
-                    "maxLength": 100,
-                    "minLength": 8
+                    "example": "JBSWY3DPEHPK3PXP"
                 }
             }
         },
-        "request.UserRegister": {
+        "response.TwoFactorRecoveryCodes": {
             "type": "object",
-            "required": [
-                "email",
-                "password",
-                "role",
-                "username"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 8
-                },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "anonymous",
-                        "reader",
-                        "author"
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "k3v9x-0qa7m"
                     ]
-                },
-                "username": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
                 }
             }
         },
-        "request.UserUpdate": {
+        "response.UserDeleted": {
             "type": "object",
-            "required": [
-                "id",
-                "role"
-            ],
             "properties": {
-                "email": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "string",
-                    "minLength": 1,
                     "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
-                },
-                "role": {
-                    "type": "string",
-                    "enum": [
-                        "anonymous",
-                        "reader",
-                        "author"
-                    ]
-                },
-                "username": {
-                    "type": "string",
-                    "maxLength": 100,
-                    "minLength": 2
                 }
             }
         },
-        "response.CommentResponse": {
+        "response.UserLogin": {
             "type": "object",
             "properties": {
-                "content": {
+                "exp": {
                     "type": "string"
                 },
-                "created_at": {
+                "refresh_exp": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string",
-                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                "refresh_token": {
+                    "type": "string"
                 },
-                "post_id": {
+                "session_id": {
                     "type": "string",
                     "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
                 },
-                "user_id": {
-                    "type": "string",
-                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+                "token": {
+                    "type": "string"
                 }
             }
         },
-        "response.PostResponse": {
+        "response.UserLogout": {
             "type": "object",
             "properties": {
-                "author_id": {
-                    "type": "string",
-                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
-                },
-                "content": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "dislikes": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
-                },
-                "likes": {
-                    "type": "integer"
-                },
-                "slug": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
-                },
-                "updated_at": {
+                "message": {
                     "type": "string"
-                },
-                "views": {
-                    "type": "integer"
                 }
             }
         },
-        "response.UserByID": {
+        "response.UserProfile": {
             "type": "object",
             "required": [
                 "role"
@@ -1483,6 +4197,9 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
+                "email_verified": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "string",
                     "example": "01ARZ3NDEKTSV4RRFFQ69G5FAV"
@@ -1492,25 +4209,43 @@ const docTemplate = `{
                     "enum": [
                         "anonymous",
                         "reader",
-                        "author"
+                        "author",
+                        "moderator",
+                        "admin"
                     ]
                 },
+                "two_factor_enabled": {
+                    "type": "boolean"
+                },
                 "username": {
                     "type": "string"
                 }
             }
         },
-        "response.UserLogout": {
+        "response.UserResponse": {
             "type": "object",
             "properties": {
                 "message": {
                     "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         }
     },
     "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Access token or API token (blog_...) as \"Bearer \u003ctoken\u003e\". Takes precedence over the token cookie.",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        },
         "CookieAuth": {
+            "description": "Access token set by /login in the token cookie. Ignored when the Authorization header is sent.",
             "type": "apiKey",
             "name": "token",
             "in": "cookie"
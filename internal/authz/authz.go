@@ -0,0 +1,101 @@
+// Package authz decides what each role can do. Roles are mapped to
+// permissions by a Policy, the default one can be replaced by a JSON file
+// without recompiling (see LoadPolicy).
+//
+// Some actions only make sense on a resource, like editing a post. Their
+// permissions come in two scopes: "post.edit.own" allows editing the posts of
+// the user, "post.edit.any" every post. Other actions, like "post.create",
+// are granted as they are.
+package authz
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+var ErrForbidden = errors.New("permission denied")
+
+type Action string
+
+const (
+	PostCreate  Action = "post.create"
+	PostEdit    Action = "post.edit"
+	PostPublish Action = "post.publish"
+	PostDelete  Action = "post.delete"
+
+	CommentCreate    Action = "comment.create"
+	CommentEdit      Action = "comment.edit"
+	CommentDelete    Action = "comment.delete"
+	CommentModerate  Action = "comment.moderate"
+	CommentReadEdits Action = "comment.edits.read"
+
+	UserEdit   Action = "user.edit"
+	UserDelete Action = "user.delete"
+	UserManage Action = "user.manage"
+
+	AuditRead Action = "audit.read"
+)
+
+// scoped are the actions granted with an .own or .any suffix.
+var scoped = map[Action]bool{
+	PostEdit:        true,
+	PostPublish:     true,
+	PostDelete:      true,
+	CommentEdit:     true,
+	CommentDelete:   true,
+	CommentModerate: true,
+	UserEdit:        true,
+	UserDelete:      true,
+}
+
+var unscoped = map[Action]bool{
+	PostCreate:       true,
+	CommentCreate:    true,
+	CommentReadEdits: true,
+	UserManage:       true,
+	AuditRead:        true,
+}
+
+// Own and Any are the permissions of a scoped action.
+func (a Action) Own() string { return string(a) + ".own" }
+func (a Action) Any() string { return string(a) + ".any" }
+
+// Resource is anything owned by a user: a post by its author, a comment by
+// its writer, a user by itself.
+type Resource interface {
+	OwnerID() pkg.ULID
+}
+
+// Owner is a resource known only by the ID of its owner, like a post that is
+// not created yet.
+type Owner pkg.ULID
+
+func (o Owner) OwnerID() pkg.ULID {
+	return pkg.ULID(o)
+}
+
+var current atomic.Pointer[Policy]
+
+func init() {
+	current.Store(DefaultPolicy())
+}
+
+// SetPolicy replaces the policy used by Can and Grants.
+func SetPolicy(policy *Policy) {
+	current.Store(policy)
+}
+
+// Can reports whether user may do action on resource. resource may be nil,
+// then only unscoped and .any permissions apply.
+func Can(user *entities.User, action Action, resource Resource) bool {
+	return current.Load().Can(user, action, resource)
+}
+
+// Grants reports whether user may do action on at least its own resources,
+// it lets middlewares refuse early before the resource is loaded.
+func Grants(user *entities.User, action Action) bool {
+	return current.Load().Grants(user, action)
+}
@@ -0,0 +1,138 @@
+package authz
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+)
+
+// Policy is the permission matrix, the permissions of each role.
+type Policy struct {
+	roles map[enums.Role]map[string]bool
+}
+
+// NewPolicy validates the matrix, unknown roles and permissions are refused
+// so a typo in the policy file does not silently lock users out.
+func NewPolicy(matrix map[enums.Role][]string) (*Policy, error) {
+	policy := &Policy{roles: map[enums.Role]map[string]bool{}}
+
+	for role, permissions := range matrix {
+		if !role.IsValid() {
+			return nil, fmt.Errorf("authz: unknown role %q", role)
+		}
+
+		granted := map[string]bool{}
+		for _, permission := range permissions {
+			if !isPermission(permission) {
+				return nil, fmt.Errorf("authz: unknown permission %q for role %s", permission, role)
+			}
+			granted[permission] = true
+		}
+		policy.roles[role] = granted
+	}
+
+	return policy, nil
+}
+
+// LoadPolicy reads a JSON object from role to permissions, like
+// {"reader": ["comment.create", "comment.edit.own"]}. Roles missing from the
+// file have no permission.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var matrix map[enums.Role][]string
+	if err := json.Unmarshal(data, &matrix); err != nil {
+		return nil, fmt.Errorf("authz: cannot parse %s: %w", path, err)
+	}
+
+	return NewPolicy(matrix)
+}
+
+// DefaultPolicy is used when no policy file is configured.
+func DefaultPolicy() *Policy {
+	reader := []string{
+		string(CommentCreate),
+		CommentEdit.Own(),
+		CommentDelete.Own(),
+		UserEdit.Own(),
+		UserDelete.Own(),
+	}
+
+	policy, err := NewPolicy(map[enums.Role][]string{
+		enums.Reader: reader,
+		enums.Author: append([]string{
+			string(PostCreate),
+			PostEdit.Own(),
+			PostPublish.Own(),
+			PostDelete.Own(),
+			CommentModerate.Own(),
+			string(CommentReadEdits),
+		}, reader...),
+		enums.Moderator: append([]string{
+			CommentDelete.Any(),
+			CommentModerate.Any(),
+			string(CommentReadEdits),
+		}, reader...),
+		enums.Admin: append([]string{
+			string(PostCreate),
+			PostEdit.Any(),
+			PostPublish.Any(),
+			PostDelete.Any(),
+			CommentDelete.Any(),
+			CommentModerate.Any(),
+			string(CommentReadEdits),
+			string(UserManage),
+			string(AuditRead),
+		}, reader...),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return policy
+}
+
+func (p *Policy) Can(user *entities.User, action Action, resource Resource) bool {
+	if user == nil {
+		return false
+	}
+
+	granted := p.roles[user.Role]
+	if !scoped[action] {
+		return granted[string(action)]
+	}
+
+	if granted[action.Any()] {
+		return true
+	}
+	return resource != nil && resource.OwnerID() == user.ID && granted[action.Own()]
+}
+
+func (p *Policy) Grants(user *entities.User, action Action) bool {
+	if user == nil {
+		return false
+	}
+
+	granted := p.roles[user.Role]
+	if !scoped[action] {
+		return granted[string(action)]
+	}
+	return granted[action.Any()] || granted[action.Own()]
+}
+
+func isPermission(permission string) bool {
+	if unscoped[Action(permission)] {
+		return true
+	}
+	for action := range scoped {
+		if permission == action.Own() || permission == action.Any() {
+			return true
+		}
+	}
+	return false
+}
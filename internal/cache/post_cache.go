@@ -62,13 +62,13 @@ func (pc *PostCache) SetAll(posts []*entities.Post) {
 	pc.listCache.Set(allPostsKey, posts)
 }
 
-func (pc *PostCache) GetPaginated(page, limit int) (PaginatedResult, bool) {
-	key := fmt.Sprintf("%s%d:%d", paginatedPrefix, page, limit)
+func (pc *PostCache) GetPaginated(page, limit int, tag string) (PaginatedResult, bool) {
+	key := fmt.Sprintf("%s%d:%d:%s", paginatedPrefix, page, limit, tag)
 	return pc.paginatedCache.Get(key)
 }
 
-func (pc *PostCache) SetPaginated(page, limit int, posts []entities.Post, total int64) {
-	key := fmt.Sprintf("%s%d:%d", paginatedPrefix, page, limit)
+func (pc *PostCache) SetPaginated(page, limit int, tag string, posts []entities.Post, total int64) {
+	key := fmt.Sprintf("%s%d:%d:%s", paginatedPrefix, page, limit, tag)
 	pc.paginatedCache.Set(key, PaginatedResult{
 		Posts: posts,
 		Total: total,
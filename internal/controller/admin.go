@@ -0,0 +1,328 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+	"github.com/clemilsonazevedo/blog/internal/dto/request"
+	"github.com/clemilsonazevedo/blog/internal/dto/response"
+	"github.com/clemilsonazevedo/blog/internal/repository"
+	"github.com/clemilsonazevedo/blog/internal/service"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"gorm.io/gorm"
+)
+
+type AdminController struct {
+	users *service.UserService
+	audit *service.AuditService
+}
+
+func NewAdminController(users *service.UserService, audit *service.AuditService) *AdminController {
+	return &AdminController{
+		users: users,
+		audit: audit,
+	}
+}
+
+// GetUsers godoc
+// @Summary List users
+// @Description Lists and searches the users (admin only)
+// @Tags Admin
+// @Produce json
+// @Param q query string false "Part of the username or email"
+// @Param role query string false "Only users with this role" Enums(anonymous, reader, author, moderator, admin)
+// @Param status query string false "Only users with this status" Enums(active, suspended, banned)
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Number of users per page (default: 10, max: 25)"
+// @Success 200 {object} map[string]interface{} "Returns data array and meta object with pagination info"
+// @Failure 400 {string} string "Invalid role or status"
+// @Failure 403 {string} string "Admin role required"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /admin/users [get]
+func (ac *AdminController) GetUsers(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 || limit > 25 {
+		limit = 10
+	}
+
+	filter := repository.UserFilter{
+		Query:  query.Get("q"),
+		Role:   enums.Role(query.Get("role")),
+		Status: enums.UserStatus(query.Get("status")),
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		exceptions.BadRequest(w, errors.New("Request Error"), "Invalid status", filter.Status)
+		return
+	}
+
+	users, total, err := ac.users.SearchUsers(filter, page, limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRole) {
+			exceptions.BadRequest(w, err, "Invalid role", filter.Role)
+			return
+		}
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot get the users", reqId)
+		return
+	}
+
+	usersObj := make([]response.AdminUserResponse, len(users))
+	for i := range users {
+		usersObj[i] = adminUserResponse(&users[i])
+	}
+
+	response.ListPosts(w, usersObj, page, limit, int(total))
+}
+
+// ChangeUserRole godoc
+// @Summary Change the role of a user
+// @Description Promotes or demotes a user (admin only). Admins cannot change their own role.
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body request.UserRoleChange true "New role"
+// @Success 200 {object} response.AdminUserResponse
+// @Failure 400 {string} string "Invalid role"
+// @Failure 403 {string} string "Admin role required"
+// @Failure 404 {string} string "User not found"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /admin/users/{id}/role [put]
+func (ac *AdminController) ChangeUserRole(w http.ResponseWriter, r *http.Request) {
+	admin, userId, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+
+	var data request.UserRoleChange
+	if !decodeAdminBody(w, r, &data) {
+		return
+	}
+
+	user, previous, err := ac.users.ChangeRole(admin, userId, data.Role)
+	if err != nil {
+		adminError(w, r, err, "Cannot change the role")
+		return
+	}
+
+	if previous != user.Role {
+		entry := auditEntry(r, admin, enums.AuditRoleChanged, "user", user.ID.String())
+		entry.Metadata = map[string]any{"from": previous, "to": user.Role}
+		ac.audit.Record(entry)
+	}
+
+	response.OK(w, "Role changed", adminUserResponse(user))
+}
+
+// SuspendUser godoc
+// @Summary Suspend a user
+// @Description Blocks the login of a user until a date and ends its sessions (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body request.UserSuspend true "End of the suspension and reason"
+// @Success 200 {object} response.AdminUserResponse
+// @Failure 400 {string} string "The suspension must end in the future"
+// @Failure 403 {string} string "Admin role required"
+// @Failure 404 {string} string "User not found"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /admin/users/{id}/suspend [post]
+func (ac *AdminController) SuspendUser(w http.ResponseWriter, r *http.Request) {
+	admin, userId, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+
+	var data request.UserSuspend
+	if !decodeAdminBody(w, r, &data) {
+		return
+	}
+
+	user, err := ac.users.SuspendUser(admin, userId, data.Until, strings.TrimSpace(data.Reason))
+	if err != nil {
+		adminError(w, r, err, "Cannot suspend the user")
+		return
+	}
+
+	entry := auditEntry(r, admin, enums.AuditUserSuspended, "user", user.ID.String())
+	entry.Metadata = map[string]any{"until": data.Until, "reason": user.StatusReason}
+	ac.audit.Record(entry)
+
+	response.OK(w, "User suspended", adminUserResponse(user))
+}
+
+// BanUser godoc
+// @Summary Ban a user
+// @Description Blocks the login of a user for good and ends its sessions (admin only)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body request.UserBan false "Reason"
+// @Success 200 {object} response.AdminUserResponse
+// @Failure 403 {string} string "Admin role required"
+// @Failure 404 {string} string "User not found"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /admin/users/{id}/ban [post]
+func (ac *AdminController) BanUser(w http.ResponseWriter, r *http.Request) {
+	admin, userId, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+
+	var data request.UserBan
+	if r.ContentLength != 0 && !decodeAdminBody(w, r, &data) {
+		return
+	}
+
+	user, err := ac.users.BanUser(admin, userId, strings.TrimSpace(data.Reason))
+	if err != nil {
+		adminError(w, r, err, "Cannot ban the user")
+		return
+	}
+
+	entry := auditEntry(r, admin, enums.AuditUserBanned, "user", user.ID.String())
+	entry.Metadata = map[string]any{"reason": user.StatusReason}
+	ac.audit.Record(entry)
+
+	response.OK(w, "User banned", adminUserResponse(user))
+}
+
+// ReactivateUser godoc
+// @Summary Reactivate a user
+// @Description Lifts the suspension or the ban of a user (admin only)
+// @Tags Admin
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.AdminUserResponse
+// @Failure 403 {string} string "Admin role required"
+// @Failure 404 {string} string "User not found"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /admin/users/{id}/reactivate [post]
+func (ac *AdminController) ReactivateUser(w http.ResponseWriter, r *http.Request) {
+	admin, userId, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := ac.users.ReactivateUser(admin, userId)
+	if err != nil {
+		adminError(w, r, err, "Cannot reactivate the user")
+		return
+	}
+
+	ac.audit.Record(auditEntry(r, admin, enums.AuditUserReactivated, "user", user.ID.String()))
+
+	response.OK(w, "User reactivated", adminUserResponse(user))
+}
+
+// ForceLogout godoc
+// @Summary Logout a user everywhere
+// @Description Ends every session of a user, its API tokens keep working (admin only)
+// @Tags Admin
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} response.UserLogout
+// @Failure 403 {string} string "Admin role required"
+// @Failure 404 {string} string "User not found"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /admin/users/{id}/logout [post]
+func (ac *AdminController) ForceLogout(w http.ResponseWriter, r *http.Request) {
+	admin, userId, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+
+	if err := ac.users.ForceLogout(userId); err != nil {
+		adminError(w, r, err, "Cannot logout the user")
+		return
+	}
+
+	ac.audit.Record(auditEntry(r, admin, enums.AuditForcedLogout, "user", userId.String()))
+
+	response.OK(w, "User logged out", response.UserLogout{
+		Message: "every session of the user was ended",
+	})
+}
+
+// adminTarget reads the admin of the context and the user of the route.
+func adminTarget(w http.ResponseWriter, r *http.Request) (*entities.User, pkg.ULID, bool) {
+	admin, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return nil, pkg.ULID{}, false
+	}
+
+	userId, err := pkg.ParseULID(chi.URLParam(r, "id"))
+	if err != nil {
+		exceptions.BadRequest(w, err, "Invalid user ID", chi.URLParam(r, "id"))
+		return nil, pkg.ULID{}, false
+	}
+
+	return admin, userId, true
+}
+
+func decodeAdminBody(w http.ResponseWriter, r *http.Request, data any) bool {
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(data); err != nil {
+		exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+		return false
+	}
+	return true
+}
+
+func adminError(w http.ResponseWriter, r *http.Request, err error, message string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		exceptions.NotFound(w, err, "User not found")
+	case errors.Is(err, service.ErrInvalidRole):
+		exceptions.BadRequest(w, err, "Invalid role", nil)
+	case errors.Is(err, service.ErrInvalidSuspension):
+		exceptions.BadRequest(w, err, "The suspension must end in the future", nil)
+	case errors.Is(err, service.ErrSelfAdministration):
+		exceptions.Forbidden(w, err, "You cannot change your own account")
+	default:
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, message, reqId)
+	}
+}
+
+func adminUserResponse(user *entities.User) response.AdminUserResponse {
+	return response.AdminUserResponse{
+		ID:       user.ID,
+		UserName: user.UserName,
+		Email:    user.Email,
+		Role:     user.Role,
+
+		EmailVerified:    user.IsEmailVerified(),
+		TwoFactorEnabled: user.IsTwoFactorEnabled(),
+
+		SuspendedUntil: user.SuspendedUntil,
+		BannedAt:       user.BannedAt,
+		StatusReason:   user.StatusReason,
+		CreatedAt:      user.CreatedAt,
+	}
+}
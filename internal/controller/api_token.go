@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+	"github.com/clemilsonazevedo/blog/internal/dto/request"
+	"github.com/clemilsonazevedo/blog/internal/dto/response"
+	"github.com/clemilsonazevedo/blog/internal/service"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type ApiTokenController struct {
+	service *service.ApiTokenService
+	audit   *service.AuditService
+}
+
+func NewApiTokenController(service *service.ApiTokenService, audit *service.AuditService) *ApiTokenController {
+	return &ApiTokenController{
+		service: service,
+		audit:   audit,
+	}
+}
+
+// CreateApiToken godoc
+// @Summary Create an API token
+// @Description Creates a scoped token to use the API from scripts as "Authorization: Bearer <token>". The token is only shown in this response.
+// @Tags Tokens
+// @Accept json
+// @Produce json
+// @Param request body request.ApiTokenCreate true "Token name, scopes and optional expiry"
+// @Success 201 {object} response.CreatedApiTokenResponse
+// @Failure 400 {string} string "Invalid scope or expiry"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /tokens [post]
+func (tc *ApiTokenController) CreateApiToken(w http.ResponseWriter, r *http.Request) {
+	var data request.ApiTokenCreate
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&data); err != nil {
+		exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+		return
+	}
+
+	data.Name = strings.TrimSpace(data.Name)
+	if data.Name == "" || len(data.Name) > 100 {
+		exceptions.BadRequest(w, errors.New("Request Error"), "The token needs a name up to 100 characters", nil)
+		return
+	}
+
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	token, secret, err := tc.service.CreateToken(contextUser, data.Name, data.Scopes, data.ExpiresAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidApiScope):
+			exceptions.BadRequest(w, err, "Invalid scopes", data.Scopes)
+		case errors.Is(err, service.ErrInvalidExpiry):
+			exceptions.BadRequest(w, err, "The expiry must be in the future", data.ExpiresAt)
+		default:
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot create the token", reqId)
+		}
+		return
+	}
+
+	entry := auditEntry(r, contextUser, enums.AuditApiTokenCreated, "api_token", token.ID.String())
+	entry.Metadata = map[string]any{"prefix": token.Prefix, "scopes": token.Scopes}
+	tc.audit.Record(entry)
+
+	response.WriteJSON(w, http.StatusCreated, response.CreatedApiTokenResponse{
+		ApiTokenResponse: apiTokenResponse(token),
+		Token:            secret,
+	})
+}
+
+// GetApiTokens godoc
+// @Summary List the API tokens
+// @Description Lists the tokens of the logged user that were not revoked, secrets are never shown again
+// @Tags Tokens
+// @Produce json
+// @Success 200 {array} response.ApiTokenResponse
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /tokens [get]
+func (tc *ApiTokenController) GetApiTokens(w http.ResponseWriter, r *http.Request) {
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	tokens, err := tc.service.GetTokens(contextUser.ID)
+	if err != nil {
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot get tokens", reqId)
+		return
+	}
+
+	tokensObj := make([]response.ApiTokenResponse, len(tokens))
+	for i := range len(tokens) {
+		tokensObj[i] = apiTokenResponse(&tokens[i])
+	}
+
+	response.OK(w, "success", tokensObj)
+}
+
+// RevokeApiToken godoc
+// @Summary Revoke an API token
+// @Description Revokes a token of the logged user, it stops working right away
+// @Tags Tokens
+// @Produce json
+// @Param id path string true "Token ULID"
+// @Success 200 {object} response.UserLogout
+// @Failure 404 {string} string "Token not found"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /tokens/{id} [delete]
+func (tc *ApiTokenController) RevokeApiToken(w http.ResponseWriter, r *http.Request) {
+	tokenId, err := pkg.ParseULID(chi.URLParam(r, "id"))
+	if err != nil {
+		exceptions.BadRequest(w, err, "Cannot Parse Token Id", chi.URLParam(r, "id"))
+		return
+	}
+
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	if err := tc.service.RevokeToken(contextUser.ID, tokenId); err != nil {
+		if errors.Is(err, service.ErrApiTokenNotFound) {
+			exceptions.NotFound(w, err, "Token not found")
+			return
+		}
+
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot revoke the token", reqId)
+		return
+	}
+
+	tc.audit.Record(auditEntry(r, contextUser, enums.AuditApiTokenRevoked, "api_token", tokenId.String()))
+
+	response.OK(w, "Token revoked", response.UserLogout{
+		Message: "token revoked with success",
+	})
+}
+
+func apiTokenResponse(t *entities.ApiToken) response.ApiTokenResponse {
+	return response.ApiTokenResponse{
+		ID:         t.ID,
+		Name:       t.Name,
+		Prefix:     t.Prefix,
+		Scopes:     t.ScopeList(),
+		ExpiresAt:  t.ExpiresAt,
+		LastUsedAt: t.LastUsedAt,
+		CreatedAt:  t.CreatedAt,
+	}
+}
@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+	"github.com/clemilsonazevedo/blog/internal/dto/response"
+	"github.com/clemilsonazevedo/blog/internal/repository"
+	"github.com/clemilsonazevedo/blog/internal/service"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type AuditController struct {
+	audit *service.AuditService
+}
+
+func NewAuditController(audit *service.AuditService) *AuditController {
+	return &AuditController{
+		audit: audit,
+	}
+}
+
+// GetAuditEvents godoc
+// @Summary List audit events
+// @Description Lists the audit log newest first (admin only). Pass the next_cursor of a page as cursor to get the next one.
+// @Tags Admin
+// @Produce json
+// @Param actor_id query string false "Only events of this user"
+// @Param action query string false "Only events of this action, like user.role.change"
+// @Param target_type query string false "Only events on this kind of target, like post or user"
+// @Param target_id query string false "Only events on this target"
+// @Param from query string false "Events created at or after this time (RFC 3339)"
+// @Param to query string false "Events created before this time (RFC 3339)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "Number of events per page (default: 50, max: 200)"
+// @Success 200 {object} response.AuditEventsPage
+// @Failure 400 {string} string "Invalid filter or cursor"
+// @Failure 403 {string} string "Admin role required"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /audit [get]
+func (ac *AuditController) GetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	filter := repository.AuditFilter{
+		Action:     enums.AuditAction(query.Get("action")),
+		TargetType: query.Get("target_type"),
+		TargetID:   query.Get("target_id"),
+	}
+
+	if actor := query.Get("actor_id"); actor != "" {
+		actorId, err := pkg.ParseULID(actor)
+		if err != nil {
+			exceptions.BadRequest(w, err, "Cannot Parse Actor Id", actor)
+			return
+		}
+		filter.ActorID = &actorId
+	}
+
+	var ok bool
+	if filter.From, ok = parseTimeParam(w, query.Get("from")); !ok {
+		return
+	}
+	if filter.To, ok = parseTimeParam(w, query.Get("to")); !ok {
+		return
+	}
+
+	events, next, err := ac.audit.ListEvents(filter, query.Get("cursor"), limit)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAuditCursor) {
+			exceptions.BadRequest(w, err, "Invalid cursor", query.Get("cursor"))
+			return
+		}
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot get the audit events", reqId)
+		return
+	}
+
+	page := response.AuditEventsPage{
+		Events:     make([]response.AuditEventResponse, len(events)),
+		NextCursor: next,
+	}
+	for i, event := range events {
+		page.Events[i] = response.AuditEventResponse{
+			ID:         event.ID,
+			ActorID:    event.ActorID,
+			Action:     event.Action,
+			TargetType: event.TargetType,
+			TargetID:   event.TargetID,
+			IP:         event.IP,
+			UserAgent:  event.UserAgent,
+			RequestID:  event.RequestID,
+			Metadata:   json.RawMessage(event.Metadata),
+			CreatedAt:  event.CreatedAt,
+		}
+	}
+
+	response.OK(w, "success", page)
+}
+
+// parseTimeParam parses an optional RFC 3339 query parameter, it writes a
+// 400 and returns false when the value is not a date.
+func parseTimeParam(w http.ResponseWriter, value string) (*time.Time, bool) {
+	if value == "" {
+		return nil, true
+	}
+
+	at, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		exceptions.BadRequest(w, err, "Dates must be in RFC 3339, like 2006-01-02T15:04:05Z", value)
+		return nil, false
+	}
+	return &at, true
+}
+
+// auditEntry fills an audit entry with the data of the request, actor can
+// be nil for anonymous requests.
+func auditEntry(r *http.Request, actor *entities.User, action enums.AuditAction, targetType string, targetID string) service.AuditEntry {
+	entry := service.AuditEntry{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		IP:         clientIP(r),
+		UserAgent:  r.UserAgent(),
+		RequestID:  middleware.GetReqID(r.Context()),
+	}
+
+	if actor != nil {
+		actorId := actor.ID
+		entry.ActorID = &actorId
+	}
+
+	return entry
+}
+
+// auditLogin records a login that started a session, method is password,
+// 2fa or the name of the login provider.
+func auditLogin(audit *service.AuditService, r *http.Request, user *entities.User, tokens *service.SessionTokens, method string) {
+	entry := auditEntry(r, user, enums.AuditLogin, "session", tokens.SessionID.String())
+	entry.Metadata = map[string]any{"method": method}
+	audit.Record(entry)
+}
+
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// truncate keeps s within the size of an audit column.
+func truncate(s string, size int) string {
+	if len(s) <= size {
+		return s
+	}
+	return s[:size]
+}
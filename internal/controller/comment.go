@@ -7,36 +7,42 @@ import (
 	"net/http"
 
 	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
 	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
 	"github.com/clemilsonazevedo/blog/internal/dto/request"
 	"github.com/clemilsonazevedo/blog/internal/dto/response"
 	"github.com/clemilsonazevedo/blog/internal/service"
 	"github.com/clemilsonazevedo/blog/pkg"
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"gorm.io/gorm"
 )
 
 type CommentController struct {
 	service *service.CommentService
+	audit   *service.AuditService
 }
 
-func NewCommentController(service *service.CommentService) *CommentController {
+func NewCommentController(service *service.CommentService, audit *service.AuditService) *CommentController {
 	return &CommentController{
 		service: service,
+		audit:   audit,
 	}
 }
 
 // CreateComment godoc
 // @Summary Create a new comment
-// @Description Creates a new comment on a post (authentication required)
+// @Description Creates a new comment on a published post (authentication required)
 // @Tags Comments
 // @Accept json
 // @Produce json
 // @Param request body request.CommentCreate true "Comment creation data"
 // @Success 201 {string} string "Comment created"
 // @Failure 400 {string} string "You need to provide all comments data"
+// @Failure 404 {string} string "This post does not exists"
 // @Failure 500 {string} string "Cannot create comment"
 // @Security CookieAuth
+// @Security BearerAuth
 // @Router /comment [post]
 func (cc *CommentController) CreateComment(w http.ResponseWriter, r *http.Request) {
 	var data request.CommentCreate
@@ -77,15 +83,21 @@ func (cc *CommentController) CreateComment(w http.ResponseWriter, r *http.Reques
 	}
 
 	Comment := entities.Comment{
-		ID:      commentId,
-		Content: data.Content,
-		UserID:  data.UserID,
-		PostID:  data.PostID,
+		ID:       commentId,
+		Content:  data.Content,
+		UserID:   data.UserID,
+		PostID:   data.PostID,
+		ParentID: data.ParentID,
 	}
 
 	if err := cc.service.CreateComment(&Comment); err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			exceptions.BadRequest(w, err, "This post does not exists", data.PostID)
+			exceptions.NotFound(w, err, "This post does not exists")
+			return
+		}
+
+		if errors.Is(err, service.ErrInvalidParentComment) || errors.Is(err, service.ErrMaxCommentDepth) {
+			exceptions.BadRequest(w, err, "Cannot reply to this comment", data.ParentID)
 			return
 		}
 
@@ -94,17 +106,23 @@ func (cc *CommentController) CreateComment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	response.CreatedComment(w, commentId)
+	entry := auditEntry(r, contextUser, enums.AuditCommentCreated, "comment", commentId.String())
+	entry.Metadata = map[string]any{"post_id": Comment.PostID, "status": Comment.Status}
+	cc.audit.Record(entry)
+
+	response.CreatedComment(w, commentId, Comment.Status)
 }
 
 // GetCommentByPostID godoc
 // @Summary Get comments by post ID
-// @Description Retrieves all comments for a specific post
+// @Description Retrieves all comments for a specific published post, as a flat list in reading order or as a tree of replies
 // @Tags Comments
 // @Produce json
 // @Param postID path string true "Post ULID"
+// @Param view query string false "flat (default) or tree"
 // @Success 200 {array} response.CommentResponse
 // @Failure 400 {string} string "Post ID is required"
+// @Failure 404 {string} string "Post not found"
 // @Failure 500 {string} string "Error retrieving comments"
 // @Router /comments/{postID} [get]
 func (cc *CommentController) GetCommentsByPostID(w http.ResponseWriter, r *http.Request) {
@@ -120,7 +138,13 @@ func (cc *CommentController) GetCommentsByPostID(w http.ResponseWriter, r *http.
 		return
 	}
 
-	comments, err := cc.service.GetCommentsByPostID(postId)
+	view := r.URL.Query().Get("view")
+	if view != "" && view != "flat" && view != "tree" {
+		exceptions.BadRequest(w, errors.New("Request Error"), "View must be flat or tree", view)
+		return
+	}
+
+	roots, err := cc.service.GetCommentTree(postId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			exceptions.NotFound(w, err, fmt.Sprintf("Post with id %v not found", postId))
@@ -131,33 +155,160 @@ func (cc *CommentController) GetCommentsByPostID(w http.ResponseWriter, r *http.
 		return
 	}
 
-	commentsObj := make([]response.CommentResponse, len(comments))
-	for i := range len(comments) {
-		p := comments[i]
-		commentsObj[i] = response.CommentResponse{
-			ID:        p.ID,
-			PostID:    p.PostID,
-			UserID:    p.UserID,
-			Content:   p.Content,
-			CreatedAt: p.CreatedAt,
+	var commentsObj []response.CommentResponse
+	if view == "tree" {
+		commentsObj = commentTreeResponse(roots)
+	} else {
+		flat := service.FlattenCommentTree(roots)
+		commentsObj = make([]response.CommentResponse, len(flat))
+		for i := range len(flat) {
+			commentsObj[i] = commentResponse(flat[i])
 		}
 	}
 
 	response.ShowComments(w, commentsObj)
 }
 
+// UpdateComment godoc
+// @Summary Edit a comment
+// @Description Edits the content of a comment. Only the owner can edit, within the configured edit window. On moderated posts an approved comment goes back to the moderation queue, like a new comment of its writer would, and its replies are hidden until it is approved again
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Comment ULID"
+// @Param request body request.CommentUpdate true "New comment content"
+// @Success 200 {object} response.CommentResponse
+// @Failure 400 {string} string "You need to provide the comment content"
+// @Failure 403 {string} string "Only the owner can edit this comment"
+// @Failure 404 {string} string "Comment Does not exists"
+// @Failure 500 {string} string "Error editing comment"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /comments/{id} [put]
+func (cc *CommentController) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	commentId, err := pkg.ParseULID(chi.URLParam(r, "id"))
+	if err != nil {
+		exceptions.BadRequest(w, err, "Cannot Parse Comment Id", chi.URLParam(r, "id"))
+		return
+	}
+
+	var data request.CommentUpdate
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&data); err != nil {
+		exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+		return
+	}
+
+	if dec.More() {
+		exceptions.BadRequest(w, errors.New("Multiple JSON values not allowed"), "multiple JSON values not allowed", nil)
+		return
+	}
+
+	if data.Content == "" {
+		exceptions.BadRequest(w, errors.New("Request Error"), "You need to provide the comment content", &data)
+		return
+	}
+
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	comment, err := cc.service.EditComment(commentId, contextUser, data.Content)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			exceptions.NotFound(w, err, "Comment Does not exists")
+		case errors.Is(err, service.ErrNotCommentOwner):
+			exceptions.Forbidden(w, err, "Only the owner can edit this comment")
+		case errors.Is(err, service.ErrCommentEditExpired):
+			exceptions.Forbidden(w, err, "This comment can no longer be edited")
+		case errors.Is(err, service.ErrCommentDeleted):
+			exceptions.BadRequest(w, err, "Deleted comments cannot be edited", commentId)
+		default:
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot edit comment", reqId)
+		}
+		return
+	}
+	cc.audit.Record(auditEntry(r, contextUser, enums.AuditCommentUpdated, "comment", commentId.String()))
+
+	response.OK(w, "Comment updated with success", commentResponse(&service.CommentNode{Comment: comment}))
+}
+
+// GetCommentEdits godoc
+// @Summary Get edit history of a comment
+// @Description Retrieves the previous contents of an edited comment, newest first. Only the author of the post and the moderators can read them
+// @Tags Comments
+// @Produce json
+// @Param id path string true "Comment ULID"
+// @Success 200 {array} response.CommentEditResponse
+// @Failure 403 {string} string "You cannot read the edits of this comment"
+// @Failure 404 {string} string "Comment Does not exists"
+// @Failure 500 {string} string "Error retrieving edits"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /comments/{id}/edits [get]
+func (cc *CommentController) GetCommentEdits(w http.ResponseWriter, r *http.Request) {
+	commentId, err := pkg.ParseULID(chi.URLParam(r, "id"))
+	if err != nil {
+		exceptions.BadRequest(w, err, "Cannot Parse Comment Id", chi.URLParam(r, "id"))
+		return
+	}
+
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	edits, err := cc.service.GetCommentEdits(contextUser, commentId)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			exceptions.NotFound(w, err, "Comment Does not exists")
+		case errors.Is(err, service.ErrNotPostModerator):
+			exceptions.Forbidden(w, err, "You cannot read the edits of this comment")
+		default:
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot get edits of this comment", reqId)
+		}
+		return
+	}
+
+	editsObj := make([]response.CommentEditResponse, len(edits))
+	for i := range len(edits) {
+		e := edits[i]
+		editsObj[i] = response.CommentEditResponse{
+			ID:              e.ID,
+			CommentID:       e.CommentID,
+			PreviousContent: e.PreviousContent,
+			EditedBy:        e.EditedBy,
+			CreatedAt:       e.CreatedAt,
+		}
+	}
+
+	response.OK(w, "success", editsObj)
+}
+
 // DeleteComment godoc
 // @Summary Delete a comment
-// @Description Deletes an existing comment
+// @Description Deletes a comment. The owner can always delete it; the post author and moderators can delete any comment on the post but must send a reason
 // @Tags Comments
-// @Param id path string true "Comment ULID"
-// @Success 204 {string} string "No Content"
+// @Accept json
+// @Param commentId query string true "Comment ULID"
+// @Param request body request.CommentDelete false "Moderation reason"
+// @Success 200 {string} string "Comment deleted"
 // @Failure 400 {string} string "ID is required"
+// @Failure 403 {string} string "You cannot delete this comment"
+// @Failure 404 {string} string "Comment Does not exists"
 // @Failure 500 {string} string "Error deleting comment"
 // @Security CookieAuth
-// @Router /comment/{id} [delete]
+// @Security BearerAuth
+// @Router /comments [delete]
 func (cc *CommentController) DeleteComment(w http.ResponseWriter, r *http.Request) {
-	// Trocar para pegar o user ID e comment Id do body para poder verificar se o usuario que criou é o que está a tentar deletar | e permitir o autor deletar qualquer comentario
 	commentIdStr := r.URL.Query().Get("commentId")
 	if commentIdStr == "" {
 		exceptions.BadRequest(w, errors.New("Request Error"), "You need Provide Comment Id on route", commentIdStr)
@@ -170,23 +321,69 @@ func (cc *CommentController) DeleteComment(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	comment, err := cc.service.GetCommentByID(commentId)
-	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			exceptions.NotFound(w, err, "Comment Does not exists")
+	var data request.CommentDelete
+	if r.ContentLength != 0 {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&data); err != nil {
+			exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
 			return
 		}
+	}
 
-		reqId := middleware.GetReqID(r.Context())
-		exceptions.InternalError(w, err, "Cannot get comment", reqId)
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
 		return
 	}
 
-	if err := cc.service.DeleteComment(comment.ID); err != nil {
-		reqId := middleware.GetReqID(r.Context())
-		exceptions.InternalError(w, err, "Cannot Delete comment", reqId)
+	if err := cc.service.DeleteCommentAs(contextUser, commentId, data.Reason); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			exceptions.NotFound(w, err, "Comment Does not exists")
+		case errors.Is(err, service.ErrCommentDeleteDenied):
+			exceptions.Forbidden(w, err, "You cannot delete this comment")
+		case errors.Is(err, service.ErrModerationReason):
+			exceptions.BadRequest(w, err, "You need to provide a reason to delete this comment", &data)
+		default:
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot Delete comment", reqId)
+		}
 		return
 	}
 
-	response.DeletedComment(w, comment.ID)
+	entry := auditEntry(r, contextUser, enums.AuditCommentDeleted, "comment", commentId.String())
+	if data.Reason != "" {
+		entry.Metadata = map[string]any{"reason": data.Reason}
+	}
+	cc.audit.Record(entry)
+
+	response.DeletedComment(w, commentId)
+}
+
+func commentResponse(node *service.CommentNode) response.CommentResponse {
+	p := node.Comment
+	return response.CommentResponse{
+		ID:        p.ID,
+		PostID:    p.PostID,
+		UserID:    p.UserID,
+		Content:   p.Content,
+		CreatedAt: p.CreatedAt,
+
+		ParentID:   p.ParentID,
+		Depth:      p.Depth,
+		ReplyCount: node.ReplyCount,
+		IsDeleted:  p.IsDeleted,
+		EditedAt:   p.EditedAt,
+		Status:     p.Status,
+	}
+}
+
+func commentTreeResponse(nodes []*service.CommentNode) []response.CommentResponse {
+	commentsObj := make([]response.CommentResponse, len(nodes))
+	for i := range len(nodes) {
+		commentsObj[i] = commentResponse(nodes[i])
+		commentsObj[i].Replies = commentTreeResponse(nodes[i].Replies)
+	}
+	return commentsObj
 }
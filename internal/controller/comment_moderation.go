@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+	"github.com/clemilsonazevedo/blog/internal/dto/request"
+	"github.com/clemilsonazevedo/blog/internal/dto/response"
+	"github.com/clemilsonazevedo/blog/internal/service"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"gorm.io/gorm"
+)
+
+// GetPendingComments godoc
+// @Summary Get the comment moderation queue
+// @Description Lists comments waiting for approval on the posts of the authenticated author, or on every post for moderators
+// @Tags Comments
+// @Produce json
+// @Param postId query string false "Only comments of this post"
+// @Success 200 {array} response.CommentResponse
+// @Failure 400 {string} string "Cannot Parse Post Id"
+// @Failure 500 {string} string "Error retrieving pending comments"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /comments/pending [get]
+func (cc *CommentController) GetPendingComments(w http.ResponseWriter, r *http.Request) {
+	var postId *pkg.ULID
+	if postIdStr := r.URL.Query().Get("postId"); postIdStr != "" {
+		id, err := pkg.ParseULID(postIdStr)
+		if err != nil {
+			exceptions.BadRequest(w, err, "Cannot Parse Post Id", postIdStr)
+			return
+		}
+		postId = &id
+	}
+
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	comments, err := cc.service.GetPendingComments(contextUser, postId)
+	if err != nil {
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot get pending comments", reqId)
+		return
+	}
+
+	commentsObj := make([]response.CommentResponse, len(comments))
+	for i := range len(comments) {
+		commentsObj[i] = commentResponse(&service.CommentNode{Comment: comments[i]})
+	}
+
+	response.ShowComments(w, commentsObj)
+}
+
+// ApproveComment godoc
+// @Summary Approve a pending comment
+// @Description Publishes a comment waiting for moderation (post author or moderator)
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Comment ULID"
+// @Param request body request.CommentReview false "Optional note"
+// @Success 200 {object} response.CommentResponse
+// @Failure 403 {string} string "You cannot moderate this comment"
+// @Failure 404 {string} string "Comment Does not exists"
+// @Failure 409 {string} string "Comment is not pending"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /comments/{id}/approve [post]
+func (cc *CommentController) ApproveComment(w http.ResponseWriter, r *http.Request) {
+	cc.reviewComment(w, r, cc.service.ApproveComment, "Comment approved with success")
+}
+
+// RejectComment godoc
+// @Summary Reject a pending comment
+// @Description Rejects a comment waiting for moderation so it is never shown (post author or moderator)
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Comment ULID"
+// @Param request body request.CommentReview false "Rejection reason"
+// @Success 200 {object} response.CommentResponse
+// @Failure 403 {string} string "You cannot moderate this comment"
+// @Failure 404 {string} string "Comment Does not exists"
+// @Failure 409 {string} string "Comment is not pending"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /comments/{id}/reject [post]
+func (cc *CommentController) RejectComment(w http.ResponseWriter, r *http.Request) {
+	cc.reviewComment(w, r, cc.service.RejectComment, "Comment rejected with success")
+}
+
+type reviewFunc func(actor *entities.User, id pkg.ULID, reason string) (*entities.Comment, error)
+
+func (cc *CommentController) reviewComment(w http.ResponseWriter, r *http.Request, review reviewFunc, message string) {
+	commentId, err := pkg.ParseULID(chi.URLParam(r, "id"))
+	if err != nil {
+		exceptions.BadRequest(w, err, "Cannot Parse Comment Id", chi.URLParam(r, "id"))
+		return
+	}
+
+	var data request.CommentReview
+	if r.ContentLength != 0 {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&data); err != nil {
+			exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+			return
+		}
+	}
+
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	comment, err := review(contextUser, commentId, data.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			exceptions.NotFound(w, err, "Comment Does not exists")
+		case errors.Is(err, service.ErrNotPostModerator):
+			exceptions.Forbidden(w, err, "You cannot moderate this comment")
+		case errors.Is(err, service.ErrCommentNotPending):
+			exceptions.Conflict(w, err, "Comment is not pending")
+		default:
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot moderate comment", reqId)
+		}
+		return
+	}
+
+	entry := auditEntry(r, contextUser, enums.AuditCommentModerated, "comment", commentId.String())
+	entry.Metadata = map[string]any{"status": comment.Status, "reason": data.Reason}
+	cc.audit.Record(entry)
+
+	response.OK(w, message, commentResponse(&service.CommentNode{Comment: comment}))
+}
+
+// SetPostCommentApproval godoc
+// @Summary Require approval for comments of a post
+// @Description Turns the moderation queue on or off for a single post (post author or moderator)
+// @Tags Comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Post ULID"
+// @Param request body request.PostCommentApproval true "Moderation mode"
+// @Success 200 {object} request.PostCommentApproval
+// @Failure 403 {string} string "You cannot moderate this post"
+// @Failure 404 {string} string "Post Does not exists"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /posts/{id}/comment-approval [put]
+func (cc *CommentController) SetPostCommentApproval(w http.ResponseWriter, r *http.Request) {
+	postId, err := pkg.ParseULID(chi.URLParam(r, "id"))
+	if err != nil {
+		exceptions.BadRequest(w, err, "Cannot Parse Post Id", chi.URLParam(r, "id"))
+		return
+	}
+
+	var data request.PostCommentApproval
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&data); err != nil {
+		exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+		return
+	}
+
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	if err := cc.service.SetPostCommentApproval(contextUser, postId, data.Required); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			exceptions.NotFound(w, err, "Post Does not exists")
+		case errors.Is(err, service.ErrNotPostModerator):
+			exceptions.Forbidden(w, err, "You cannot moderate this post")
+		default:
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot change comment moderation of this post", reqId)
+		}
+		return
+	}
+
+	response.OK(w, "Comment moderation updated with success", data)
+}
@@ -0,0 +1,17 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/clemilsonazevedo/blog/internal/dto/response"
+	"github.com/clemilsonazevedo/blog/internal/http/auth"
+)
+
+// GetJWKS serves the public keys of the access tokens (JSON Web Key Set) so
+// other services can verify them, picking the key by the kid header. It is
+// mounted at /.well-known/jwks.json, outside the versioned API. The set is
+// empty when the tokens are signed with the shared secret.
+func GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	response.WriteJSON(w, http.StatusOK, auth.CurrentKeyring().JWKS())
+}
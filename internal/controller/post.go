@@ -6,25 +6,32 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/clemilsonazevedo/blog/internal/authz"
 	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
 	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
 	"github.com/clemilsonazevedo/blog/internal/dto/request"
 	"github.com/clemilsonazevedo/blog/internal/dto/response"
 	"github.com/clemilsonazevedo/blog/internal/service"
 	"github.com/clemilsonazevedo/blog/pkg"
 	"github.com/clemilsonazevedo/blog/tools"
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"gorm.io/gorm"
 )
 
 type PostController struct {
 	service *service.PostService
+	audit   *service.AuditService
 }
 
-func NewPostController(service *service.PostService) *PostController {
+func NewPostController(service *service.PostService, audit *service.AuditService) *PostController {
 	return &PostController{
 		service: service,
+		audit:   audit,
 	}
 }
 
@@ -39,6 +46,7 @@ func NewPostController(service *service.PostService) *PostController {
 // @Failure 400 {string} string "You need set Content and AuthorId to create a post"
 // @Failure 500 {string} string "Cannot create post"
 // @Security CookieAuth
+// @Security BearerAuth
 // @Router /post [post]
 func (pc *PostController) CreatePost(w http.ResponseWriter, r *http.Request) {
 	var postDTO request.PostCreate
@@ -75,6 +83,15 @@ func (pc *PostController) CreatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if postDTO.Language == "" {
+		postDTO.Language = enums.Portuguese
+	}
+
+	if !postDTO.Language.IsValid() {
+		exceptions.BadRequest(w, errors.New("Request Error"), "Language must be pt-br or en", postDTO.Language)
+		return
+	}
+
 	postId, err := pkg.NewULID()
 	if err != nil {
 		reqId := middleware.GetReqID(r.Context())
@@ -89,12 +106,26 @@ func (pc *PostController) CreatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	tags, err := pc.service.ResolveTags(postDTO.Tags)
+	if err != nil {
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot save Tags of this Post", reqId)
+		return
+	}
+
 	Post := entities.Post{
 		ID:       postId,
 		Title:    postDTO.Title,
 		Slug:     slug,
 		Content:  postDTO.Content,
+		Language: postDTO.Language,
 		AuthorId: authorId,
+		Tags:     tags,
+	}
+
+	if err := pc.service.SetStatus(&Post, postDTO.Status, postDTO.ScheduledFor); err != nil {
+		exceptions.BadRequest(w, err, "Invalid status for this Post", postDTO.Status)
+		return
 	}
 
 	if err := pc.service.CreatePost(&Post); err != nil {
@@ -102,6 +133,7 @@ func (pc *PostController) CreatePost(w http.ResponseWriter, r *http.Request) {
 		exceptions.InternalError(w, err, "Cannot create Post", reqId)
 		return
 	}
+	pc.auditPost(r, enums.AuditPostCreated, &Post, map[string]any{"status": Post.Status})
 
 	response.CreatedPost(w, postId, authorId)
 }
@@ -117,6 +149,7 @@ func (pc *PostController) CreatePost(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {string} string "You need set Content and AuthorId to create a post"
 // @Failure 500 {string} string "Cannot create post"
 // @Security CookieAuth
+// @Security BearerAuth
 // @Router /post-with-ai [post]
 func (pc *PostController) CreatePostWithAi(w http.ResponseWriter, r *http.Request) {
 	var aiPostDTO request.AiPostCreate
@@ -169,12 +202,31 @@ func (pc *PostController) CreatePostWithAi(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	tags, err := pc.service.ResolveTags(append(aiPostDTO.Tags, aiRes.Hashtags...))
+	if err != nil {
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot save Tags of this Post", reqId)
+		return
+	}
+
+	language := enums.Language(aiRes.Language)
+	if !language.IsValid() {
+		language = enums.Portuguese
+	}
+
 	Post := entities.Post{
 		ID:       aiPostId,
 		Title:    aiRes.Title,
 		Slug:     slug,
-		Content:  pkg.GeneratePostContent(aiPostDTO.Content, aiRes.Hashtags),
+		Content:  aiPostDTO.Content,
+		Language: language,
 		AuthorId: authorId,
+		Tags:     tags,
+	}
+
+	if err := pc.service.SetStatus(&Post, aiPostDTO.Status, aiPostDTO.ScheduledFor); err != nil {
+		exceptions.BadRequest(w, err, "Invalid status for this Post", aiPostDTO.Status)
+		return
 	}
 
 	if err := pc.service.CreatePost(&Post); err != nil {
@@ -182,6 +234,7 @@ func (pc *PostController) CreatePostWithAi(w http.ResponseWriter, r *http.Reques
 		exceptions.InternalError(w, err, "Cannot create this post", reqId)
 		return
 	}
+	pc.auditPost(r, enums.AuditPostCreated, &Post, map[string]any{"status": Post.Status, "ai": true})
 
 	response.CreatedPost(w, aiPostId, authorId)
 }
@@ -227,11 +280,90 @@ func (pc *PostController) GetPostById(w http.ResponseWriter, r *http.Request) {
 		Title:     post.Title,
 		Slug:      post.Slug,
 		Content:   post.Content,
+		Language:  post.Language,
+		AuthorId:  post.AuthorId,
+		Likes:     post.Likes,
+		Dislikes:  post.Dislikes,
+		Views:     post.Views,
+		Tags:      tagNames(post.Tags),
+		CreatedAt: post.CreatedAt,
+
+		Status:       post.Status,
+		PublishedAt:  post.PublishedAt,
+		ScheduledFor: post.ScheduledFor,
+	}
+
+	if contextUser, ok := r.Context().Value("user").(*entities.User); ok {
+		reaction, err := pc.service.GetUserReaction(contextUser.ID, post.ID)
+		if err != nil {
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot get reaction of this post", reqId)
+			return
+		}
+		postObj.UserReaction = reaction
+	}
+
+	response.ShowPost(w, postObj)
+}
+
+// GetPostBySlug godoc
+// @Summary Get post by slug
+// @Description Retrieves a single post by its slug, redirecting old slugs to the current one
+// @Tags Posts
+// @Produce json
+// @Param slug path string true "Post slug"
+// @Success 200 {object} response.PostResponse
+// @Success 301 {string} string "Moved to the current slug"
+// @Failure 404 {string} string "Post not found"
+// @Failure 500 {string} string "Error retrieving post"
+// @Router /posts/{slug} [get]
+func (pc *PostController) GetPostBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	if slug == "" {
+		exceptions.BadRequest(w, errors.New("Request Error"), "You need to provide Post slug", slug)
+		return
+	}
+
+	post, err := pc.service.GetPostBySlug(slug)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot get this post", reqId)
+			return
+		}
+
+		currentSlug, err := pc.service.GetCurrentSlug(slug)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				exceptions.NotFound(w, err, fmt.Sprintf("Post with slug %v not found", slug))
+				return
+			}
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot get this post", reqId)
+			return
+		}
+
+		target := strings.TrimSuffix(r.URL.Path, slug) + currentSlug
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+		return
+	}
+
+	postObj := response.PostResponse{
+		ID:        post.ID,
+		Title:     post.Title,
+		Slug:      post.Slug,
+		Content:   post.Content,
+		Language:  post.Language,
 		AuthorId:  post.AuthorId,
 		Likes:     post.Likes,
 		Dislikes:  post.Dislikes,
 		Views:     post.Views,
+		Tags:      tagNames(post.Tags),
 		CreatedAt: post.CreatedAt,
+
+		Status:       post.Status,
+		PublishedAt:  post.PublishedAt,
+		ScheduledFor: post.ScheduledFor,
 	}
 
 	response.ShowPost(w, postObj)
@@ -248,6 +380,7 @@ func (pc *PostController) GetPostById(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param page query int true "Page number (default: 1)"
 // @Param limit query int true "Number of posts per page (default: 10, max: 100)"
+// @Param tag query string false "Only return posts with this tag"
 // @Success 200 {object} map[string]interface{} "Returns data array and meta object with pagination info"
 // @Failure 400 {string} string "Page is required"
 // @Failure 400 {string} string "Limit is required"
@@ -283,7 +416,9 @@ func (c *PostController) GetPaginatedPosts(w http.ResponseWriter, r *http.Reques
 		limit = 10
 	}
 
-	posts, total, err := c.service.GetPaginatedPosts(page, limit)
+	tag := r.URL.Query().Get("tag")
+
+	posts, total, err := c.service.GetPaginatedPosts(page, limit, tag)
 	if err != nil {
 		reqId := middleware.GetReqID(r.Context())
 		exceptions.InternalError(w, err, "Cannot get paginated Posts", reqId)
@@ -298,15 +433,75 @@ func (c *PostController) GetPaginatedPosts(w http.ResponseWriter, r *http.Reques
 			Title:     p.Title,
 			Slug:      p.Slug,
 			Content:   p.Content,
+			Language:  p.Language,
 			Views:     p.Views,
 			AuthorId:  p.AuthorId,
+			Tags:      tagNames(p.Tags),
 			CreatedAt: p.CreatedAt,
+
+			Status:      p.Status,
+			PublishedAt: p.PublishedAt,
 		}
 	}
 
 	response.ListPosts(w, postsObj, page, limit, int(total))
 }
 
+// SearchPosts godoc
+// @Summary Search posts
+// @Description Full text search over published posts, ranked by relevance with highlighted snippets. Snippets are escaped HTML where only the <mark> tags around the matches are markup
+// @Tags Posts
+// @Produce json
+// @Param q query string true "Search terms (supports quotes, OR and -exclusion)"
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Number of results per page (default: 10, max: 25)"
+// @Success 200 {object} map[string]interface{} "Returns data array and meta object with pagination info"
+// @Failure 400 {string} string "Search query is required"
+// @Failure 500 {string} string "Error searching posts"
+// @Router /search [get]
+func (pc *PostController) SearchPosts(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		exceptions.BadRequest(w, errors.New("Request Error"), "You need provide a search query", query)
+		return
+	}
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 || limit > 25 {
+		limit = 10
+	}
+
+	results, total, err := pc.service.SearchPosts(query, page, limit)
+	if err != nil {
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot search Posts", reqId)
+		return
+	}
+
+	resultsObj := make([]response.PostSearchResponse, len(results))
+	for i := range len(results) {
+		p := results[i]
+		resultsObj[i] = response.PostSearchResponse{
+			ID:          p.ID,
+			Title:       p.Title,
+			Slug:        p.Slug,
+			Language:    p.Language,
+			AuthorId:    p.AuthorId,
+			Snippet:     p.Snippet,
+			Rank:        p.Rank,
+			CreatedAt:   p.CreatedAt,
+			PublishedAt: p.PublishedAt,
+		}
+	}
+
+	response.ListPosts(w, resultsObj, page, limit, int(total))
+}
+
 // UpdatePost godoc
 // @Summary Update a post
 // @Description Updates an existing blog post (Author role required)
@@ -319,6 +514,7 @@ func (c *PostController) GetPaginatedPosts(w http.ResponseWriter, r *http.Reques
 // @Failure 400 {string} string "ID is required"
 // @Failure 500 {string} string "Error updating post"
 // @Security CookieAuth
+// @Security BearerAuth
 // @Router /post/{id} [put]
 func (pc *PostController) UpdatePost(w http.ResponseWriter, r *http.Request) {
 	postIdStr := r.URL.Query().Get("postId")
@@ -333,7 +529,7 @@ func (pc *PostController) UpdatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	existingPost, err := pc.service.GetPostByID(postId)
+	existingPost, err := pc.service.FindPostByID(postId)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			exceptions.NotFound(w, err, "Post Does not exists")
@@ -345,6 +541,10 @@ func (pc *PostController) UpdatePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !authorizePost(w, r, authz.PostEdit, existingPost) {
+		return
+	}
+
 	var updatePostDTO request.PostUpdate
 	dec := json.NewDecoder(r.Body)
 	dec.DisallowUnknownFields()
@@ -363,7 +563,7 @@ func (pc *PostController) UpdatePost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if len(updatePostDTO.Title) >= 1 {
-		slug, err := pc.service.GenerateUniqueSlug(updatePostDTO.Title)
+		slug, err := pc.service.RegenerateSlug(existingPost, updatePostDTO.Title)
 		if err != nil {
 			reqId := middleware.GetReqID(r.Context())
 			exceptions.InternalError(w, err, "Cannot Generate Slug to this Post", reqId)
@@ -377,17 +577,42 @@ func (pc *PostController) UpdatePost(w http.ResponseWriter, r *http.Request) {
 		updatePostDTO.Content = existingPost.Content
 	}
 
+	if updatePostDTO.Language == "" {
+		updatePostDTO.Language = existingPost.Language
+	}
+
+	if !updatePostDTO.Language.IsValid() {
+		exceptions.BadRequest(w, errors.New("Request Error"), "Language must be pt-br or en", updatePostDTO.Language)
+		return
+	}
+
+	tags := existingPost.Tags
+	if updatePostDTO.Tags != nil {
+		tags, err = pc.service.ResolveTags(updatePostDTO.Tags)
+		if err != nil {
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot save Tags of this Post", reqId)
+			return
+		}
+	}
+
 	postObj := entities.Post{
 		ID:        existingPost.ID,
 		AuthorId:  existingPost.AuthorId,
 		Title:     updatePostDTO.Title,
 		Content:   updatePostDTO.Content,
+		Language:  updatePostDTO.Language,
 		Slug:      existingPost.Slug,
 		Author:    existingPost.Author,
 		Likes:     existingPost.Likes,
 		Views:     existingPost.Views,
 		Dislikes:  existingPost.Dislikes,
+		Tags:      tags,
 		CreatedAt: existingPost.CreatedAt,
+
+		Status:       existingPost.Status,
+		PublishedAt:  existingPost.PublishedAt,
+		ScheduledFor: existingPost.ScheduledFor,
 	}
 
 	if err := pc.service.UpdatePost(&postObj); err != nil {
@@ -395,6 +620,7 @@ func (pc *PostController) UpdatePost(w http.ResponseWriter, r *http.Request) {
 		exceptions.InternalError(w, err, "Cannot update this post", reqId)
 		return
 	}
+	pc.auditPost(r, enums.AuditPostUpdated, &postObj, nil)
 
 	response.OK(w, "Comment updated with success", postObj)
 }
@@ -408,6 +634,7 @@ func (pc *PostController) UpdatePost(w http.ResponseWriter, r *http.Request) {
 // @Failure 400 {string} string "ID is required"
 // @Failure 500 {string} string "Error deleting post"
 // @Security CookieAuth
+// @Security BearerAuth
 // @Router /post/{id} [delete]
 func (pc *PostController) DeletePost(w http.ResponseWriter, r *http.Request) {
 	postIdStr := r.URL.Query().Get("postId")
@@ -422,7 +649,7 @@ func (pc *PostController) DeletePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	existingPost, err := pc.service.GetPostByID(postId)
+	existingPost, err := pc.service.FindPostByID(postId)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			exceptions.NotFound(w, err, "Post Does not exists")
@@ -434,11 +661,211 @@ func (pc *PostController) DeletePost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !authorizePost(w, r, authz.PostDelete, existingPost) {
+		return
+	}
+
 	if err := pc.service.DeletePost(existingPost.ID); err != nil {
 		reqId := middleware.GetReqID(r.Context())
 		exceptions.InternalError(w, err, "Cannot delete this post", reqId)
 		return
 	}
+	pc.auditPost(r, enums.AuditPostDeleted, existingPost, map[string]any{"title": existingPost.Title})
 
 	response.DeletedPost(w, existingPost.ID)
 }
+
+// GetDrafts godoc
+// @Summary Get drafts of the current author
+// @Description Retrieves the draft and scheduled posts of the authenticated author (Author role required)
+// @Tags Posts
+// @Produce json
+// @Success 200 {array} response.PostResponse
+// @Failure 401 {string} string "unauthorized"
+// @Failure 500 {string} string "Error retrieving drafts"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /drafts [get]
+func (pc *PostController) GetDrafts(w http.ResponseWriter, r *http.Request) {
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	posts, err := pc.service.GetDraftsByAuthor(contextUser.ID)
+	if err != nil {
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot get drafts", reqId)
+		return
+	}
+
+	postsObj := make([]response.PostResponse, len(posts))
+	for i := range len(posts) {
+		p := posts[i]
+		postsObj[i] = response.PostResponse{
+			ID:        p.ID,
+			Title:     p.Title,
+			Slug:      p.Slug,
+			Content:   p.Content,
+			Language:  p.Language,
+			AuthorId:  p.AuthorId,
+			Tags:      tagNames(p.Tags),
+			CreatedAt: p.CreatedAt,
+
+			Status:       p.Status,
+			ScheduledFor: p.ScheduledFor,
+		}
+	}
+
+	response.ShowPost(w, postsObj)
+}
+
+// PublishPost godoc
+// @Summary Publish a post
+// @Description Publishes a post now, or schedules it when scheduled_for is a future date (Author role required)
+// @Tags Posts
+// @Accept json
+// @Produce json
+// @Param postId query string true "Post ULID"
+// @Param request body request.PostPublish false "Optional publication date"
+// @Success 200 {object} response.PostResponse
+// @Failure 400 {string} string "Invalid status for this Post"
+// @Failure 404 {string} string "Post Does not exists"
+// @Failure 500 {string} string "Error publishing post"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /posts/publish [post]
+func (pc *PostController) PublishPost(w http.ResponseWriter, r *http.Request) {
+	var publishDTO request.PostPublish
+	if r.ContentLength != 0 {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&publishDTO); err != nil {
+			exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+			return
+		}
+	}
+
+	pc.changeStatus(w, r, enums.Published, publishDTO.ScheduledFor)
+}
+
+// UnpublishPost godoc
+// @Summary Unpublish a post
+// @Description Moves a published or scheduled post back to draft (Author role required)
+// @Tags Posts
+// @Produce json
+// @Param postId query string true "Post ULID"
+// @Success 200 {object} response.PostResponse
+// @Failure 404 {string} string "Post Does not exists"
+// @Failure 500 {string} string "Error unpublishing post"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /posts/unpublish [post]
+func (pc *PostController) UnpublishPost(w http.ResponseWriter, r *http.Request) {
+	pc.changeStatus(w, r, enums.Draft, nil)
+}
+
+// ArchivePost godoc
+// @Summary Archive a post
+// @Description Removes a post from the public listing without deleting it (Author role required)
+// @Tags Posts
+// @Produce json
+// @Param postId query string true "Post ULID"
+// @Success 200 {object} response.PostResponse
+// @Failure 404 {string} string "Post Does not exists"
+// @Failure 500 {string} string "Error archiving post"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /posts/archive [post]
+func (pc *PostController) ArchivePost(w http.ResponseWriter, r *http.Request) {
+	pc.changeStatus(w, r, enums.Archived, nil)
+}
+
+func (pc *PostController) changeStatus(w http.ResponseWriter, r *http.Request, status enums.PostStatus, scheduledFor *time.Time) {
+	postIdStr := r.URL.Query().Get("postId")
+	if postIdStr == "" {
+		exceptions.BadRequest(w, errors.New("Request Error"), "You need Provide Post Id on route", postIdStr)
+		return
+	}
+
+	postId, err := pkg.ParseULID(postIdStr)
+	if err != nil {
+		exceptions.BadRequest(w, err, "Cannot Parse Post Id", postId)
+		return
+	}
+
+	existingPost, err := pc.service.FindPostByID(postId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			exceptions.NotFound(w, err, "Post Does not exists")
+			return
+		}
+
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot get post", reqId)
+		return
+	}
+
+	if !authorizePost(w, r, authz.PostPublish, existingPost) {
+		return
+	}
+
+	previous := existingPost.Status
+	if err := pc.service.ChangeStatus(existingPost, status, scheduledFor); err != nil {
+		if errors.Is(err, service.ErrInvalidPostStatus) || errors.Is(err, service.ErrInvalidSchedule) {
+			exceptions.BadRequest(w, err, "Invalid status for this Post", status)
+			return
+		}
+
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot change status of this post", reqId)
+		return
+	}
+	pc.auditPost(r, enums.AuditPostStatusChanged, existingPost, map[string]any{"from": previous, "to": existingPost.Status})
+
+	response.OK(w, "Post status updated with success", response.PostResponse{
+		ID:        existingPost.ID,
+		Title:     existingPost.Title,
+		Slug:      existingPost.Slug,
+		AuthorId:  existingPost.AuthorId,
+		CreatedAt: existingPost.CreatedAt,
+
+		Status:       existingPost.Status,
+		PublishedAt:  existingPost.PublishedAt,
+		ScheduledFor: existingPost.ScheduledFor,
+	})
+}
+
+// auditPost records an action of the logged user on post.
+func (pc *PostController) auditPost(r *http.Request, action enums.AuditAction, post *entities.Post, metadata map[string]any) {
+	user, _ := r.Context().Value("user").(*entities.User)
+	entry := auditEntry(r, user, action, "post", post.ID.String())
+	entry.Metadata = metadata
+	pc.audit.Record(entry)
+}
+
+// authorizePost refuses the request when the user cannot do action on post,
+// like editing the post of another author.
+func authorizePost(w http.ResponseWriter, r *http.Request, action authz.Action, post *entities.Post) bool {
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return false
+	}
+
+	if !authz.Can(contextUser, action, post) {
+		exceptions.Forbidden(w, authz.ErrForbidden, "You cannot change this post")
+		return false
+	}
+
+	return true
+}
+
+func tagNames(tags []entities.Tag) []string {
+	names := make([]string, len(tags))
+	for i := range len(tags) {
+		names[i] = tags[i].Name
+	}
+	return names
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+	"github.com/clemilsonazevedo/blog/internal/dto/request"
+	"github.com/clemilsonazevedo/blog/internal/dto/response"
+	"github.com/clemilsonazevedo/blog/internal/service"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"gorm.io/gorm"
+)
+
+// ReactToPost godoc
+// @Summary Like or dislike a post
+// @Description Sets the reaction of the authenticated user to a post, replacing any previous one
+// @Tags Posts
+// @Accept json
+// @Produce json
+// @Param id path string true "Post ULID"
+// @Param request body request.PostReaction true "Reaction"
+// @Success 200 {object} response.PostReactionResponse
+// @Failure 400 {string} string "Reaction must be like or dislike"
+// @Failure 404 {string} string "Post not found"
+// @Failure 500 {string} string "Error saving reaction"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /posts/{id}/reaction [put]
+func (pc *PostController) ReactToPost(w http.ResponseWriter, r *http.Request) {
+	postId, err := pkg.ParseULID(chi.URLParam(r, "id"))
+	if err != nil {
+		exceptions.BadRequest(w, err, "Cannot parse Id of Post", chi.URLParam(r, "id"))
+		return
+	}
+
+	var reactionDTO request.PostReaction
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&reactionDTO); err != nil {
+		exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+		return
+	}
+
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	post, err := pc.service.React(contextUser.ID, postId, reactionDTO.Reaction)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidReaction) {
+			exceptions.BadRequest(w, err, "Reaction must be like or dislike", reactionDTO.Reaction)
+			return
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			exceptions.NotFound(w, err, fmt.Sprintf("Post with id %v not found", postId))
+			return
+		}
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot react to this post", reqId)
+		return
+	}
+
+	response.OK(w, "Reaction saved with success", response.PostReactionResponse{
+		PostID:   post.ID,
+		Reaction: reactionDTO.Reaction,
+		Likes:    post.Likes,
+		Dislikes: post.Dislikes,
+	})
+}
+
+// RemovePostReaction godoc
+// @Summary Remove reaction from a post
+// @Description Removes the like or dislike of the authenticated user from a post
+// @Tags Posts
+// @Produce json
+// @Param id path string true "Post ULID"
+// @Success 200 {object} response.PostReactionResponse
+// @Failure 404 {string} string "Post not found"
+// @Failure 500 {string} string "Error removing reaction"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /posts/{id}/reaction [delete]
+func (pc *PostController) RemovePostReaction(w http.ResponseWriter, r *http.Request) {
+	postId, err := pkg.ParseULID(chi.URLParam(r, "id"))
+	if err != nil {
+		exceptions.BadRequest(w, err, "Cannot parse Id of Post", chi.URLParam(r, "id"))
+		return
+	}
+
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	post, err := pc.service.RemoveReaction(contextUser.ID, postId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			exceptions.NotFound(w, err, fmt.Sprintf("Post with id %v not found", postId))
+			return
+		}
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot remove reaction of this post", reqId)
+		return
+	}
+
+	response.OK(w, "Reaction removed with success", response.PostReactionResponse{
+		PostID:   post.ID,
+		Likes:    post.Likes,
+		Dislikes: post.Dislikes,
+	})
+}
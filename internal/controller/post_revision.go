@@ -0,0 +1,202 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/clemilsonazevedo/blog/internal/authz"
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+	"github.com/clemilsonazevedo/blog/internal/dto/response"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"gorm.io/gorm"
+)
+
+// GetPostRevisions godoc
+// @Summary Get revisions of a post
+// @Description Retrieves every saved revision of a post, newest first (Author role required)
+// @Tags Posts
+// @Produce json
+// @Param id path string true "Post ULID"
+// @Success 200 {array} response.PostRevisionResponse
+// @Failure 400 {string} string "Cannot parse Id of Post"
+// @Failure 404 {string} string "Post not found"
+// @Failure 500 {string} string "Error retrieving revisions"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /posts/{id}/revisions [get]
+func (pc *PostController) GetPostRevisions(w http.ResponseWriter, r *http.Request) {
+	postId, err := pkg.ParseULID(chi.URLParam(r, "id"))
+	if err != nil {
+		exceptions.BadRequest(w, err, "Cannot parse Id of Post", chi.URLParam(r, "id"))
+		return
+	}
+
+	if _, ok := pc.findAuthorizedPost(w, r, authz.PostEdit, postId); !ok {
+		return
+	}
+
+	revisions, err := pc.service.GetRevisions(postId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			exceptions.NotFound(w, err, fmt.Sprintf("Post with id %v not found", postId))
+			return
+		}
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot get revisions of this post", reqId)
+		return
+	}
+
+	revisionsObj := make([]response.PostRevisionResponse, len(revisions))
+	for i := range len(revisions) {
+		rev := revisions[i]
+		revisionsObj[i] = response.PostRevisionResponse{
+			ID:        rev.ID,
+			PostID:    rev.PostID,
+			Revision:  rev.Revision,
+			Title:     rev.Title,
+			Slug:      rev.Slug,
+			Content:   rev.Content,
+			CreatedAt: rev.CreatedAt,
+		}
+	}
+
+	response.OK(w, "success", revisionsObj)
+}
+
+// DiffPostRevision godoc
+// @Summary Diff a revision against the current post
+// @Description Returns a line based unified diff from the revision content to the current content (Author role required)
+// @Tags Posts
+// @Produce json
+// @Param id path string true "Post ULID"
+// @Param rev path int true "Revision number"
+// @Success 200 {object} response.PostRevisionDiffResponse
+// @Failure 400 {string} string "Cannot parse revision number"
+// @Failure 404 {string} string "Revision not found"
+// @Failure 500 {string} string "Error building diff"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /posts/{id}/revisions/{rev}/diff [get]
+func (pc *PostController) DiffPostRevision(w http.ResponseWriter, r *http.Request) {
+	postId, revision, ok := parseRevisionParams(w, r)
+	if !ok {
+		return
+	}
+
+	if _, ok := pc.findAuthorizedPost(w, r, authz.PostEdit, postId); !ok {
+		return
+	}
+
+	diff, err := pc.service.DiffRevision(postId, revision)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			exceptions.NotFound(w, err, fmt.Sprintf("Revision %d of post %v not found", revision, postId))
+			return
+		}
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot diff this revision", reqId)
+		return
+	}
+
+	response.OK(w, "success", response.PostRevisionDiffResponse{
+		PostID:   postId,
+		Revision: revision,
+		Diff:     diff,
+	})
+}
+
+// RestorePostRevision godoc
+// @Summary Restore a revision
+// @Description Replaces the post title and content with a revision, saving the current version as a new revision (Author role required)
+// @Tags Posts
+// @Produce json
+// @Param id path string true "Post ULID"
+// @Param rev path int true "Revision number"
+// @Success 200 {object} response.PostResponse
+// @Failure 400 {string} string "Cannot parse revision number"
+// @Failure 404 {string} string "Revision not found"
+// @Failure 500 {string} string "Error restoring revision"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /posts/{id}/revisions/{rev}/restore [post]
+func (pc *PostController) RestorePostRevision(w http.ResponseWriter, r *http.Request) {
+	postId, revision, ok := parseRevisionParams(w, r)
+	if !ok {
+		return
+	}
+
+	if _, ok := pc.findAuthorizedPost(w, r, authz.PostEdit, postId); !ok {
+		return
+	}
+
+	post, err := pc.service.RestoreRevision(postId, revision)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			exceptions.NotFound(w, err, fmt.Sprintf("Revision %d of post %v not found", revision, postId))
+			return
+		}
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot restore this revision", reqId)
+		return
+	}
+	pc.auditPost(r, enums.AuditPostUpdated, post, map[string]any{"restored_revision": revision})
+
+	response.OK(w, "Revision restored with success", response.PostResponse{
+		ID:        post.ID,
+		Title:     post.Title,
+		Slug:      post.Slug,
+		Content:   post.Content,
+		AuthorId:  post.AuthorId,
+		Likes:     post.Likes,
+		Dislikes:  post.Dislikes,
+		Views:     post.Views,
+		Tags:      tagNames(post.Tags),
+		CreatedAt: post.CreatedAt,
+
+		Status:       post.Status,
+		PublishedAt:  post.PublishedAt,
+		ScheduledFor: post.ScheduledFor,
+	})
+}
+
+// findAuthorizedPost loads the post and checks the user can do action on it.
+func (pc *PostController) findAuthorizedPost(w http.ResponseWriter, r *http.Request, action authz.Action, postId pkg.ULID) (*entities.Post, bool) {
+	post, err := pc.service.FindPostByID(postId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			exceptions.NotFound(w, err, fmt.Sprintf("Post with id %v not found", postId))
+			return nil, false
+		}
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot get post", reqId)
+		return nil, false
+	}
+
+	if !authorizePost(w, r, action, post) {
+		return nil, false
+	}
+
+	return post, true
+}
+
+func parseRevisionParams(w http.ResponseWriter, r *http.Request) (pkg.ULID, int, bool) {
+	postId, err := pkg.ParseULID(chi.URLParam(r, "id"))
+	if err != nil {
+		exceptions.BadRequest(w, err, "Cannot parse Id of Post", chi.URLParam(r, "id"))
+		return pkg.ULID{}, 0, false
+	}
+
+	revision, err := strconv.Atoi(chi.URLParam(r, "rev"))
+	if err != nil || revision <= 0 {
+		exceptions.BadRequest(w, errors.New("Request Error"), "Cannot parse revision number", chi.URLParam(r, "rev"))
+		return pkg.ULID{}, 0, false
+	}
+
+	return postId, revision, true
+}
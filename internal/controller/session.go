@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+	"github.com/clemilsonazevedo/blog/internal/dto/request"
+	"github.com/clemilsonazevedo/blog/internal/dto/response"
+	"github.com/clemilsonazevedo/blog/internal/service"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const (
+	refreshCookie = "refresh_token"
+	// refreshCookiePath limits the refresh token to the refresh route, the
+	// other routes never see it
+	refreshCookiePath = "/api/v1/refresh"
+)
+
+// RefreshSession godoc
+// @Summary Refresh the session
+// @Description Exchanges a refresh token (body or refresh_token cookie) for a new access token and a new refresh token. Each refresh token works once, using it again revokes the whole session.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.SessionRefresh false "Refresh token, when not sent as cookie"
+// @Success 200 {object} response.UserLogin
+// @Failure 401 {string} string "Invalid or expired refresh token"
+// @Router /refresh [post]
+func (uc *UserController) RefreshSession(w http.ResponseWriter, r *http.Request) {
+	var data request.SessionRefresh
+	if r.ContentLength != 0 {
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&data); err != nil {
+			exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+			return
+		}
+	}
+
+	if data.RefreshToken == "" {
+		if cookie, err := r.Cookie(refreshCookie); err == nil {
+			data.RefreshToken = cookie.Value
+		}
+	}
+
+	if data.RefreshToken == "" {
+		exceptions.Unauthorized(w, "Refresh token is missing!")
+		return
+	}
+
+	tokens, session, err := uc.sessions.Refresh(data.RefreshToken, sessionMeta(r, ""))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrRefreshTokenReused):
+			entry := auditEntry(r, nil, enums.AuditRefreshTokenReused, "session", session.FamilyID.String())
+			userId := session.UserID
+			entry.ActorID = &userId
+			uc.audit.Record(entry)
+
+			clearSessionCookies(w)
+			exceptions.Unauthorized(w, "Refresh token already used, the session was revoked.")
+		case errors.Is(err, service.ErrInvalidRefreshToken):
+			clearSessionCookies(w)
+			exceptions.Unauthorized(w, "Invalid or expired refresh token")
+		default:
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot refresh this session", reqId)
+		}
+		return
+	}
+
+	response.OK(w, "Session refreshed", setSessionCookies(w, tokens))
+}
+
+// GetSessions godoc
+// @Summary List the active sessions
+// @Description Lists where the logged user is logged in
+// @Tags Auth
+// @Produce json
+// @Success 200 {array} response.SessionResponse
+// @Failure 401 {string} string "unauthorized"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /sessions [get]
+func (uc *UserController) GetSessions(w http.ResponseWriter, r *http.Request) {
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	sessions, err := uc.sessions.GetActiveSessions(contextUser.ID)
+	if err != nil {
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot get sessions", reqId)
+		return
+	}
+
+	currentId, _ := r.Context().Value("sessionId").(pkg.ULID)
+	sessionsObj := make([]response.SessionResponse, len(sessions))
+	for i := range len(sessions) {
+		s := sessions[i]
+		sessionsObj[i] = response.SessionResponse{
+			ID:         s.FamilyID,
+			Device:     s.Device,
+			IP:         s.IP,
+			UserAgent:  s.UserAgent,
+			SignedInAt: s.SignedInAt,
+			LastUsedAt: s.CreatedAt,
+			ExpiresAt:  s.ExpiresAt,
+			Current:    s.FamilyID == currentId,
+		}
+	}
+
+	response.OK(w, "success", sessionsObj)
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Logs the user out of one of its sessions
+// @Tags Auth
+// @Produce json
+// @Param id path string true "Session ULID"
+// @Success 200 {object} response.UserLogout
+// @Failure 404 {string} string "Session not found"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /sessions/{id} [delete]
+func (uc *UserController) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	sessionId, err := pkg.ParseULID(chi.URLParam(r, "id"))
+	if err != nil {
+		exceptions.BadRequest(w, err, "Cannot Parse Session Id", chi.URLParam(r, "id"))
+		return
+	}
+
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	if err := uc.sessions.RevokeSession(contextUser.ID, sessionId); err != nil {
+		if errors.Is(err, service.ErrSessionNotFound) {
+			exceptions.NotFound(w, err, "Session not found")
+			return
+		}
+
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot revoke this session", reqId)
+		return
+	}
+
+	uc.audit.Record(auditEntry(r, contextUser, enums.AuditSessionRevoked, "session", sessionId.String()))
+
+	if currentId, _ := r.Context().Value("sessionId").(pkg.ULID); currentId == sessionId {
+		clearSessionCookies(w)
+	}
+
+	response.OK(w, "Session revoked", response.UserLogout{
+		Message: "session revoked with success",
+	})
+}
+
+func sessionMeta(r *http.Request, device string) service.SessionMeta {
+	return service.SessionMeta{
+		Device:    device,
+		IP:        clientIP(r),
+		UserAgent: r.UserAgent(),
+	}
+}
+
+func setSessionCookies(w http.ResponseWriter, tokens *service.SessionTokens) response.UserLogin {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "token",
+		Value:    tokens.AccessToken,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+		Expires:  tokens.AccessExpires,
+		MaxAge:   max(int(time.Until(tokens.AccessExpires).Seconds()), 0),
+	})
+	http.SetCookie(w, &http.Cookie{
+		Name:     refreshCookie,
+		Value:    tokens.RefreshToken,
+		Path:     refreshCookiePath,
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+		Expires:  tokens.RefreshExpires,
+		MaxAge:   max(int(time.Until(tokens.RefreshExpires).Seconds()), 0),
+	})
+
+	return response.UserLogin{
+		Token:          tokens.AccessToken,
+		Expires:        tokens.AccessExpires,
+		SessionID:      tokens.SessionID,
+		RefreshToken:   tokens.RefreshToken,
+		RefreshExpires: tokens.RefreshExpires,
+	}
+}
+
+func clearSessionCookies(w http.ResponseWriter) {
+	cookies := []struct{ name, path string }{
+		{"token", "/"},
+		{refreshCookie, refreshCookiePath},
+	}
+	for _, cookie := range cookies {
+		http.SetCookie(w, &http.Cookie{
+			Name:     cookie.name,
+			Value:    "",
+			Path:     cookie.path,
+			HttpOnly: true,
+			Secure:   false,
+			MaxAge:   -1,
+		})
+	}
+}
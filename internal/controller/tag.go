@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+	"github.com/clemilsonazevedo/blog/internal/dto/response"
+	"github.com/clemilsonazevedo/blog/internal/service"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+type TagController struct {
+	service *service.TagService
+}
+
+func NewTagController(service *service.TagService) *TagController {
+	return &TagController{
+		service: service,
+	}
+}
+
+// GetAllTags godoc
+// @Summary Get all tags
+// @Description Retrieves every tag with the number of posts using it
+// @Tags Tags
+// @Produce json
+// @Success 200 {array} response.TagResponse
+// @Failure 500 {string} string "Error retrieving tags"
+// @Router /tags [get]
+func (tc *TagController) GetAllTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := tc.service.GetAllTags()
+	if err != nil {
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot get tags", reqId)
+		return
+	}
+
+	tagsObj := make([]response.TagResponse, len(tags))
+	for i := range len(tags) {
+		t := tags[i]
+		tagsObj[i] = response.TagResponse{
+			ID:        t.ID,
+			Name:      t.Name,
+			Slug:      t.Slug,
+			PostCount: t.PostCount,
+		}
+	}
+
+	response.ListTags(w, tagsObj)
+}
@@ -3,10 +3,11 @@ package controller
 import (
 	"encoding/json"
 	"errors"
+	"log"
 	"net/http"
 	"net/mail"
-	"time"
 
+	"github.com/clemilsonazevedo/blog/internal/authz"
 	"github.com/clemilsonazevedo/blog/internal/domain/entities"
 	"github.com/clemilsonazevedo/blog/internal/domain/enums"
 	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
@@ -22,12 +23,19 @@ type User = entities.User
 type UserService = service.UserService
 
 type UserController struct {
-	service *UserService
+	service  *UserService
+	sessions *service.SessionService
+	audit    *service.AuditService
+	guard    *service.LoginGuard
 }
 
-func NewUserController(service *service.UserService) *UserController {
+func NewUserController(service *service.UserService, sessions *service.SessionService, audit *service.AuditService,
+	guard *service.LoginGuard) *UserController {
 	return &UserController{
-		service: service,
+		service:  service,
+		sessions: sessions,
+		audit:    audit,
+		guard:    guard,
 	}
 }
 
@@ -38,9 +46,11 @@ func NewUserController(service *service.UserService) *UserController {
 // @Accept json
 // @Produce plain
 // @Param request body request.UserRegister true "User registration data"
+// @Description A confirmation link is sent to the email, only verified users can comment
 // @Success 201 {string} string "User Created has successfully"
 // @Failure 400 {string} string "You need provide all credentials"
 // @Failure 400 {string} string "Password need 8 or more characters"
+// @Failure 400 {string} string "Password is too common"
 // @Failure 409 {string} string "User Already Exists"
 // @Failure 500 {string} string "Cannot create user"
 // @Router /register [post]
@@ -63,8 +73,7 @@ func (uc *UserController) CreateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if len(data.Password) < 8 {
-		exceptions.BadRequest(w, errors.New("Request Failed"), "Password need 8 or more characters", data.Password)
+	if !uc.checkPasswordPolicy(w, data.Password, data.Email, data.UserName) {
 		return
 	}
 
@@ -108,20 +117,28 @@ func (uc *UserController) CreateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The account exists even if the email is not delivered, the user can
+	// ask for a new one in /verify-email/resend
+	if err := uc.service.SendVerificationEmail(&user); err != nil {
+		log.Printf("cannot send verification email to user %s: %v", user.ID, err)
+	}
+
 	response.CreatedUser(w, userId.String())
 }
 
 // LoginUser godoc
 // @Summary Login user
-// @Description Authenticates a user and sets a JWT token in an HTTP-only cookie
+// @Description Authenticates a user and starts a session: a short lived access token and a refresh token are set in HTTP-only cookies.
+// @Description When the account uses two factor authentication no session is started, a challenge token is returned for /login/2fa instead (response.TwoFactorChallenge).
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Param request body request.UserLogin true "User login credentials"
-// @Success 200 {string} string "Login successful - token set in cookie"
+// @Success 200 {object} response.UserLogin "Login successful - tokens set in cookies"
 // @Failure 400 {string} string "Email and Password are Required"
-// @Failure 400 {string} string "Email or Password is incorrect"
-// @Header 200 {string} Set-Cookie "token=<jwt>; Path=/; HttpOnly; SameSite=Lax"
+// @Failure 400 {string} string "Email or Password is incorrect, the same answer whether the email exists or not"
+// @Failure 429 {string} string "Too many failed attempts, see the Retry-After header"
+// @Header 200 {string} Set-Cookie "token=<jwt>; Path=/; HttpOnly; SameSite=Lax, refresh_token=<token>; Path=/api/v1/refresh; HttpOnly; SameSite=Lax"
 // @Router /login [post]
 func (uc *UserController) LoginUser(w http.ResponseWriter, r *http.Request) {
 	var data request.UserLogin
@@ -142,61 +159,100 @@ func (uc *UserController) LoginUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authUser, err := uc.service.GetUserByEmail(data.Email)
+	ip := clientIP(r)
+	if wait, err := uc.guard.Check(data.Email, ip); err != nil {
+		exceptions.TooManyRequests(w, err, "Too many failed attempts, try again later", wait)
+		return
+	}
+
+	authUser, err := uc.service.CheckCredentials(data.Email, data.Password)
 	if err != nil {
-		exceptions.BadRequest(w, err, "User does not exists", data)
+		if !errors.Is(err, service.ErrInvalidCredentials) {
+			uc.guard.Release(data.Email, ip)
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot verify the credentials", reqId)
+			return
+		}
+
+		uc.recordLoginFailure(r, nil, data.Email, ip)
+		exceptions.BadRequest(w, errors.New("Request Error"), "Email or password incorrect", nil)
+		return
+	}
+
+	// With two factor the login only succeeds with the code, the failures of
+	// the account (wrong codes included) are kept until then
+	if authUser.IsTwoFactorEnabled() {
+		uc.guard.Release(data.Email, ip)
+	} else {
+		uc.guard.RecordSuccess(data.Email, ip)
+	}
+
+	if err := uc.service.CheckAccountStatus(authUser); err != nil {
+		exceptions.Forbidden(w, err, "This account is suspended or banned")
 		return
 	}
 
-	isPasswordEquals := auth.CheckPassword(authUser.Password, data.Password)
-	if !isPasswordEquals {
-		exceptions.BadRequest(w, errors.New("Request Error"), "Email or password incorrect", data)
+	if authUser.IsTwoFactorEnabled() {
+		challenge, exp, err := uc.service.StartTwoFactorLogin(authUser, data.Device)
+		if err != nil {
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot start two factor login", reqId)
+			return
+		}
+
+		response.OK(w, "Two factor code required", response.TwoFactorChallenge{
+			TwoFactorRequired: true,
+			ChallengeToken:    challenge,
+			Expires:           exp,
+		})
 		return
 	}
 
-	token, exp, err := auth.GenerateJWT(*authUser, 24*7*time.Hour)
+	tokens, err := uc.sessions.StartSession(authUser, sessionMeta(r, data.Device))
 	if err != nil {
 		reqId := middleware.GetReqID(r.Context())
 		exceptions.InternalError(w, err, "Cannot generate JWT to this Session", reqId)
 		return
 	}
+	auditLogin(uc.audit, r, authUser, tokens, "password")
 
-	expires := time.Unix(exp, 0)
-	maxAge := max(int(exp-time.Now().Unix()), 0)
-	http.SetCookie(w, &http.Cookie{
-		Name:     "token",
-		Value:    token,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   false,
-		SameSite: http.SameSiteLaxMode,
-		Expires:  expires,
-		MaxAge:   maxAge,
-	})
+	response.OK(w, "User has logged successfully", setSessionCookies(w, tokens))
+}
 
-	response.OK(w, "User has logged successfully", response.UserLogin{
-		Token:   token,
-		Expires: expires,
-	})
+// recordLoginFailure counts a wrong password or code in the login guard and
+// audits it, with the lockouts it started.
+func (uc *UserController) recordLoginFailure(r *http.Request, user *entities.User, email string, ip string) {
+	uc.audit.Record(auditEntry(r, user, enums.AuditLoginFailed, "email", truncate(email, 64)))
+
+	for _, lockout := range uc.guard.RecordFailure(email, ip) {
+		entry := auditEntry(r, nil, enums.AuditLoginLockout, lockout.Kind, truncate(lockout.Key, 64))
+		entry.Metadata = map[string]any{"key": lockout.Key, "failures": lockout.Failures, "until": lockout.Until}
+		uc.audit.Record(entry)
+	}
 }
 
 // Logout godoc
 // @Summary Logout user
-// @Description Clears the authentication cookie and logs out the user
+// @Description Revokes the current session and clears the authentication cookies
 // @Tags Auth
 // @Produce json
 // @Success 200 {object} response.UserLogout "Logout successful"
 // @Security CookieAuth
+// @Security BearerAuth
 // @Router /logout [post]
 func (uc *UserController) Logout(w http.ResponseWriter, r *http.Request) {
-	http.SetCookie(w, &http.Cookie{
-		Name:     "token",
-		Value:    "",
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   false,
-		MaxAge:   -1,
-	})
+	if sessionId, ok := r.Context().Value("sessionId").(pkg.ULID); ok {
+		if err := uc.sessions.EndSession(sessionId); err != nil {
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot end this session", reqId)
+			return
+		}
+
+		user, _ := r.Context().Value("user").(*entities.User)
+		uc.audit.Record(auditEntry(r, user, enums.AuditLogout, "session", sessionId.String()))
+	}
+
+	clearSessionCookies(w)
 
 	response.OK(w, "Logged Out", response.UserLogout{
 		Message: "user has logged out with success",
@@ -208,9 +264,10 @@ func (uc *UserController) Logout(w http.ResponseWriter, r *http.Request) {
 // @Description Retrieves the profile of the currently authenticated user
 // @Tags Users
 // @Produce json
-// @Success 200 {object} response.UserByID
+// @Success 200 {object} response.UserProfile
 // @Failure 401 {string} string "unauthorized"
 // @Security CookieAuth
+// @Security BearerAuth
 // @Router /profile [get]
 func (uc *UserController) Profile(w http.ResponseWriter, r *http.Request) {
 	user, ok := r.Context().Value("user").(*entities.User)
@@ -224,6 +281,9 @@ func (uc *UserController) Profile(w http.ResponseWriter, r *http.Request) {
 		UserName: user.UserName,
 		Email:    user.Email,
 		Role:     user.Role,
+
+		EmailVerified:    user.IsEmailVerified(),
+		TwoFactorEnabled: user.IsTwoFactorEnabled(),
 	}
 
 	response.OK(w, "success", resp)
@@ -235,10 +295,11 @@ func (uc *UserController) Profile(w http.ResponseWriter, r *http.Request) {
 // @Tags Users
 // @Produce json
 // @Param email query string true "User email"
-// @Success 200 {object} response.UserByID
+// @Success 200 {object} response.UserProfile
 // @Failure 400 {string} string "Email is required"
 // @Failure 500 {string} string "Error retrieving user"
 // @Security CookieAuth
+// @Security BearerAuth
 // @Router /user [get]
 func (uc *UserController) GetUserByEmail(w http.ResponseWriter, r *http.Request) {
 	email := r.URL.Query().Get("email")
@@ -277,6 +338,7 @@ func (uc *UserController) GetUserByEmail(w http.ResponseWriter, r *http.Request)
 // @Failure 400 {string} string "Cannot Parse String to ULID"
 // @Failure 500 {string} string "Error updating user"
 // @Security CookieAuth
+// @Security BearerAuth
 // @Router /profile [put]
 func (uc *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
 	userIdStr := r.URL.Query().Get("userId")
@@ -310,11 +372,6 @@ func (uc *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if contextUser.ID != userId {
-		exceptions.Unauthorized(w, "Cannot update other user")
-		return
-	}
-
 	// Meio inutil, estou a procurar uma utilidade para isso, mas vou encontrar...
 	existingUser, err := uc.service.GetUserByID(userId)
 	if err != nil {
@@ -322,6 +379,11 @@ func (uc *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !authz.Can(contextUser, authz.UserEdit, existingUser) {
+		exceptions.Forbidden(w, authz.ErrForbidden, "Cannot update other user")
+		return
+	}
+
 	user := entities.User{
 		ID:       existingUser.ID,
 		UserName: userDTO.UserName,
@@ -345,10 +407,11 @@ func (uc *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
 // @Tags Users
 // @Produce json
 // @Param id path string true "User ULID"
-// @Success 200 {object} response.UserByID
+// @Success 200 {object} response.UserDeleted
 // @Failure 400 {string} string "ID is required"
 // @Failure 500 {string} string "Error deleting user"
 // @Security CookieAuth
+// @Security BearerAuth
 // @Router /profile [delete]
 func (uc *UserController) DeleteUser(w http.ResponseWriter, r *http.Request) {
 	userIdStr := r.URL.Query().Get("userId")
@@ -375,8 +438,8 @@ func (uc *UserController) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if contextUser.ID != existingUser.ID {
-		exceptions.Unauthorized(w, "Cannot delete other user")
+	if !authz.Can(contextUser, authz.UserDelete, existingUser) {
+		exceptions.Forbidden(w, authz.ErrForbidden, "Cannot delete other user")
 		return
 	}
 
@@ -386,15 +449,10 @@ func (uc *UserController) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if contextUser.ID == existingUser.ID {
+		clearSessionCookies(w)
+	}
 	response.OK(w, "User Deleted With Success", response.UserDeleted{
 		ID: userId,
 	})
-	http.SetCookie(w, &http.Cookie{
-		Name:     "token",
-		Value:    "",
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   false,
-		MaxAge:   -1,
-	})
 }
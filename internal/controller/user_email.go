@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/mail"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+	"github.com/clemilsonazevedo/blog/internal/dto/request"
+	"github.com/clemilsonazevedo/blog/internal/dto/response"
+	"github.com/clemilsonazevedo/blog/internal/service"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// ChangeEmail godoc
+// @Summary Change the email
+// @Description Sends a confirmation link to the new address. The email of the account only changes after it is confirmed in /profiles/email/confirm.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body request.EmailChange true "New email and current password"
+// @Success 202 {object} response.UserResponse
+// @Failure 400 {string} string "You need Provide a valid email"
+// @Failure 403 {string} string "Current password is incorrect"
+// @Failure 409 {string} string "Email already in use"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /profiles/email [put]
+func (uc *UserController) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	var data request.EmailChange
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&data); err != nil {
+		exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+		return
+	}
+
+	if data.Email == "" || data.Password == "" {
+		exceptions.BadRequest(w, errors.New("Request Error"), "Email and Password are Required", nil)
+		return
+	}
+
+	email, err := mail.ParseAddress(data.Email)
+	if err != nil {
+		exceptions.BadRequest(w, err, "You need Provide a valid email", data.Email)
+		return
+	}
+
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	if err := uc.service.RequestEmailChange(contextUser, data.Password, email.Address); err != nil {
+		switch {
+		case errors.Is(err, service.ErrWrongPassword):
+			exceptions.Forbidden(w, err, "Current password is incorrect")
+		case errors.Is(err, service.ErrSameEmail):
+			exceptions.BadRequest(w, err, "This is already your email", nil)
+		case errors.Is(err, service.ErrEmailTaken):
+			exceptions.Conflict(w, err, "Email already in use")
+		default:
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot send the confirmation email", reqId)
+		}
+		return
+	}
+
+	entry := auditEntry(r, contextUser, enums.AuditEmailChangeRequested, "user", contextUser.ID.String())
+	entry.Metadata = map[string]any{"from": contextUser.Email, "to": email.Address}
+	uc.audit.Record(entry)
+
+	response.WriteJSON(w, http.StatusAccepted, response.UserResponse{
+		Message:   "confirmation sent to the new email",
+		UserID:    contextUser.ID.String(),
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+// ConfirmEmailChange godoc
+// @Summary Confirm the new email
+// @Description Switches the email of the account with the token sent by /profiles/email. Every session of the account is revoked, login again with the new email.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body request.EmailChangeConfirm true "Confirmation token"
+// @Success 200 {object} response.UserProfile
+// @Failure 400 {string} string "Invalid or expired token"
+// @Failure 409 {string} string "Email already in use"
+// @Router /profiles/email/confirm [post]
+func (uc *UserController) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	var data request.EmailChangeConfirm
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&data); err != nil {
+		exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+		return
+	}
+
+	if data.Token == "" {
+		exceptions.BadRequest(w, errors.New("Request Error"), "The token is required", nil)
+		return
+	}
+
+	user, previous, err := uc.service.ConfirmEmailChange(data.Token)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidEmailChangeToken):
+			exceptions.BadRequest(w, err, "Invalid or expired token", nil)
+		case errors.Is(err, service.ErrEmailTaken):
+			exceptions.Conflict(w, err, "Email already in use")
+		default:
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot change the email", reqId)
+		}
+		return
+	}
+
+	entry := auditEntry(r, user, enums.AuditEmailChanged, "user", user.ID.String())
+	entry.Metadata = map[string]any{"from": previous, "to": user.Email}
+	uc.audit.Record(entry)
+
+	clearSessionCookies(w)
+
+	response.OK(w, "Email changed with success", response.UserProfile{
+		ID:       user.ID,
+		UserName: user.UserName,
+		Email:    user.Email,
+		Role:     user.Role,
+
+		EmailVerified: true,
+	})
+}
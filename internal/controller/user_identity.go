@@ -0,0 +1,229 @@
+package controller
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+	"github.com/clemilsonazevedo/blog/internal/dto/response"
+	"github.com/clemilsonazevedo/blog/internal/http/auth"
+	"github.com/clemilsonazevedo/blog/internal/oidc"
+	"github.com/clemilsonazevedo/blog/internal/service"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const oidcStateCookie = "oidc_state"
+
+type IdentityController struct {
+	providers oidc.Registry
+	users     *service.UserService
+	sessions  *service.SessionService
+	audit     *service.AuditService
+	stateTTL  time.Duration
+}
+
+func NewIdentityController(providers oidc.Registry, users *service.UserService, sessions *service.SessionService,
+	audit *service.AuditService, stateTTL time.Duration) *IdentityController {
+	return &IdentityController{
+		providers: providers,
+		users:     users,
+		sessions:  sessions,
+		audit:     audit,
+		stateTTL:  stateTTL,
+	}
+}
+
+// GetProviders godoc
+// @Summary List the login providers
+// @Description Names of the external OpenID Connect providers, login with GET /auth/{provider}
+// @Tags Auth
+// @Produce json
+// @Success 200 {array} string
+// @Router /auth/providers [get]
+func (ic *IdentityController) GetProviders(w http.ResponseWriter, r *http.Request) {
+	names := make([]string, 0, len(ic.providers))
+	for name := range ic.providers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	response.OK(w, "Login providers", names)
+}
+
+// LoginWithProvider godoc
+// @Summary Login with an external provider
+// @Description Redirects to the OpenID Connect provider (authorization code flow with PKCE), the provider redirects back to /auth/{provider}/callback
+// @Tags Auth
+// @Param provider path string true "Provider name"
+// @Success 302 {string} string "Redirect to the provider"
+// @Failure 404 {string} string "Unknown provider"
+// @Router /auth/{provider} [get]
+func (ic *IdentityController) LoginWithProvider(w http.ResponseWriter, r *http.Request) {
+	provider, err := ic.providers.Get(chi.URLParam(r, "provider"))
+	if err != nil {
+		exceptions.NotFound(w, err, "Unknown login provider")
+		return
+	}
+
+	state := auth.OIDCState{Provider: provider.Name()}
+	for _, value := range []*string{&state.State, &state.Nonce, &state.Verifier} {
+		if *value, err = oidc.RandomString(); err != nil {
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot start the login", reqId)
+			return
+		}
+	}
+
+	redirect, err := provider.AuthCodeURL(r.Context(), state.State, state.Nonce, state.Verifier)
+	if err != nil {
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "The login provider is unavailable", reqId)
+		return
+	}
+
+	stateToken, exp, err := auth.GenerateOIDCStateToken(state, ic.stateTTL)
+	if err != nil {
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot start the login", reqId)
+		return
+	}
+
+	// Lax so the cookie comes back with the redirect of the provider
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    stateToken,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   false,
+		SameSite: http.SameSiteLaxMode,
+		Expires:  exp,
+		MaxAge:   int(ic.stateTTL.Seconds()),
+	})
+
+	http.Redirect(w, r, redirect, http.StatusFound)
+}
+
+// ProviderCallback godoc
+// @Summary Complete an external login
+// @Description The provider redirects here after the login. The provider account is linked to the user with the same verified email, or a new user is created. Returns a two factor challenge when the user has two factor enabled.
+// @Tags Auth
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Param code query string true "Authorization code"
+// @Param state query string true "State sent to the provider"
+// @Success 200 {object} response.UserLogin
+// @Failure 400 {string} string "Invalid state or login refused by the provider"
+// @Failure 401 {string} string "Invalid ID token"
+// @Failure 409 {string} string "Email used by another account"
+// @Router /auth/{provider}/callback [get]
+func (ic *IdentityController) ProviderCallback(w http.ResponseWriter, r *http.Request) {
+	provider, err := ic.providers.Get(chi.URLParam(r, "provider"))
+	if err != nil {
+		exceptions.NotFound(w, err, "Unknown login provider")
+		return
+	}
+
+	cookie, err := r.Cookie(oidcStateCookie)
+	if err != nil {
+		exceptions.BadRequest(w, auth.ErrInvalidOIDCState, "The login expired, try again", nil)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   -1,
+	})
+
+	query := r.URL.Query()
+	if providerError := query.Get("error"); providerError != "" {
+		exceptions.BadRequest(w, errors.New(providerError), "The login was refused by the provider", query.Get("error_description"))
+		return
+	}
+
+	state, err := auth.ValidateOIDCStateToken(cookie.Value)
+	if err != nil || state.Provider != provider.Name() ||
+		subtle.ConstantTimeCompare([]byte(state.State), []byte(query.Get("state"))) != 1 {
+		exceptions.BadRequest(w, auth.ErrInvalidOIDCState, "The login expired, try again", nil)
+		return
+	}
+
+	code := query.Get("code")
+	if code == "" {
+		exceptions.BadRequest(w, errors.New("Request Error"), "Authorization code is required", nil)
+		return
+	}
+
+	claims, err := provider.Exchange(r.Context(), code, state.Verifier, state.Nonce)
+	if err != nil {
+		if errors.Is(err, oidc.ErrInvalidIDToken) {
+			exceptions.Unauthorized(w, "Invalid ID token")
+			return
+		}
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot complete the login with the provider", reqId)
+		return
+	}
+
+	user, login, err := ic.users.LoginWithIdentity(provider.Name(), claims)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrIdentityEmailMissing):
+			exceptions.BadRequest(w, err, "The provider must share your email address", nil)
+		case errors.Is(err, service.ErrIdentityEmailNotVerified):
+			exceptions.Conflict(w, err, "This email is used by another account, login with your password")
+		default:
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot login with the provider", reqId)
+		}
+		return
+	}
+
+	switch login {
+	case service.IdentityLinked:
+		entry := auditEntry(r, user, enums.AuditIdentityLinked, "user", user.ID.String())
+		entry.Metadata = map[string]any{"provider": provider.Name(), "subject": claims.Subject}
+		ic.audit.Record(entry)
+	case service.IdentityCreated:
+		entry := auditEntry(r, user, enums.AuditIdentityRegistered, "user", user.ID.String())
+		entry.Metadata = map[string]any{"provider": provider.Name(), "subject": claims.Subject}
+		ic.audit.Record(entry)
+	}
+
+	if err := ic.users.CheckAccountStatus(user); err != nil {
+		exceptions.Forbidden(w, err, "This account is suspended or banned")
+		return
+	}
+
+	if user.IsTwoFactorEnabled() {
+		challenge, exp, err := ic.users.StartTwoFactorLogin(user, provider.Name())
+		if err != nil {
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot start two factor login", reqId)
+			return
+		}
+
+		response.OK(w, "Two factor code required", response.TwoFactorChallenge{
+			TwoFactorRequired: true,
+			ChallengeToken:    challenge,
+			Expires:           exp,
+		})
+		return
+	}
+
+	tokens, err := ic.sessions.StartSession(user, sessionMeta(r, provider.Name()))
+	if err != nil {
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot generate JWT to this Session", reqId)
+		return
+	}
+	auditLogin(ic.audit, r, user, tokens, provider.Name())
+
+	response.OK(w, "User has logged successfully", setSessionCookies(w, tokens))
+}
@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+	"github.com/clemilsonazevedo/blog/internal/dto/request"
+	"github.com/clemilsonazevedo/blog/internal/dto/response"
+	"github.com/clemilsonazevedo/blog/internal/service"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// ForgotPassword godoc
+// @Summary Ask for a password reset
+// @Description Emails a single use link to reset the password. The answer is always the same, registered or not, so it cannot be used to find which emails exist.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.PasswordForgot true "Account email"
+// @Success 202 {object} response.UserResponse
+// @Failure 400 {string} string "Cannot Decode Body"
+// @Router /password/forgot [post]
+func (uc *UserController) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var data request.PasswordForgot
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&data); err != nil {
+		exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+		return
+	}
+
+	if data.Email == "" {
+		exceptions.BadRequest(w, errors.New("Request Error"), "Email is required", nil)
+		return
+	}
+
+	// Failures are only logged, answering differently would tell the caller
+	// that the email exists
+	if err := uc.service.ForgotPassword(data.Email); err != nil {
+		reqId := middleware.GetReqID(r.Context())
+		log.Printf("password forgot [%s]: %v", reqId, err)
+	}
+
+	response.WriteJSON(w, http.StatusAccepted, response.UserResponse{
+		Message:   "if this email is registered, a reset link was sent to it",
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+// ResetPassword godoc
+// @Summary Reset the password
+// @Description Sets a new password with the token sent by /password/forgot and logs the user out of every session
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.PasswordReset true "Reset token and new password"
+// @Success 200 {object} response.UserResponse
+// @Failure 400 {string} string "Invalid or expired token"
+// @Failure 400 {string} string "Password need 8 or more characters"
+// @Failure 400 {string} string "Password is too common"
+// @Router /password/reset [post]
+func (uc *UserController) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var data request.PasswordReset
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&data); err != nil {
+		exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+		return
+	}
+
+	if data.Token == "" {
+		exceptions.BadRequest(w, errors.New("Request Error"), "The token is required", nil)
+		return
+	}
+
+	if !uc.checkPasswordPolicy(w, data.Password) {
+		return
+	}
+
+	if err := uc.service.ResetPassword(data.Token, data.Password); err != nil {
+		if errors.Is(err, service.ErrInvalidResetToken) {
+			exceptions.BadRequest(w, err, "Invalid or expired token", nil)
+			return
+		}
+
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot reset the password", reqId)
+		return
+	}
+
+	response.OK(w, "Password reset with success", response.UserResponse{
+		Message:   "password changed, login again in every device",
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+// ChangePassword godoc
+// @Summary Change the password
+// @Description Changes the password of the logged user. Every session is revoked and a new one is started for this client.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body request.PasswordChange true "Current and new password"
+// @Success 200 {object} response.UserLogin
+// @Failure 400 {string} string "Password need 8 or more characters"
+// @Failure 400 {string} string "Password is too common"
+// @Failure 403 {string} string "Current password is incorrect"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /profiles/password [put]
+func (uc *UserController) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var data request.PasswordChange
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&data); err != nil {
+		exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+		return
+	}
+
+	if data.CurrentPassword == "" || data.NewPassword == "" {
+		exceptions.BadRequest(w, errors.New("Request Error"), "Current and new password are required", nil)
+		return
+	}
+
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	if !uc.checkPasswordPolicy(w, data.NewPassword, contextUser.Email, contextUser.UserName) {
+		return
+	}
+
+	if err := uc.service.ChangePassword(contextUser, data.CurrentPassword, data.NewPassword); err != nil {
+		if errors.Is(err, service.ErrWrongPassword) {
+			exceptions.Forbidden(w, err, "Current password is incorrect")
+			return
+		}
+
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot change the password", reqId)
+		return
+	}
+
+	uc.audit.Record(auditEntry(r, contextUser, enums.AuditPasswordChanged, "user", contextUser.ID.String()))
+
+	tokens, err := uc.sessions.StartSession(contextUser, sessionMeta(r, ""))
+	if err != nil {
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Password changed, but cannot generate a new session", reqId)
+		return
+	}
+
+	response.OK(w, "Password changed with success", setSessionCookies(w, tokens))
+}
+
+// checkPasswordPolicy writes a 400 and returns false when a new password
+// breaks the password policy. The words are the email and username of the
+// account, when known.
+func (uc *UserController) checkPasswordPolicy(w http.ResponseWriter, password string, words ...string) bool {
+	policy := uc.service.PasswordPolicy()
+	err := policy.Check(password, words...)
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, service.ErrPasswordTooShort):
+		exceptions.BadRequest(w, err, fmt.Sprintf("Password need %d or more characters", policy.MinLength), nil)
+	case errors.Is(err, service.ErrPasswordTooLong):
+		exceptions.BadRequest(w, err, fmt.Sprintf("Password need %d bytes or less", policy.MaxLength), nil)
+	default:
+		exceptions.BadRequest(w, err, "Password is too common", nil)
+	}
+	return false
+}
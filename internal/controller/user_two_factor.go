@@ -0,0 +1,242 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+	"github.com/clemilsonazevedo/blog/internal/dto/request"
+	"github.com/clemilsonazevedo/blog/internal/dto/response"
+	"github.com/clemilsonazevedo/blog/internal/service"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// EnrollTwoFactor godoc
+// @Summary Start two factor enrollment
+// @Description Creates a TOTP secret for the logged user. Add it to an authenticator app (the otpauth URI can be shown as a QR code) and confirm it with /profiles/2fa/confirm.
+// @Tags Users
+// @Produce json
+// @Success 200 {object} response.TwoFactorEnrollment
+// @Failure 409 {string} string "Two factor already enabled"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /profiles/2fa/enroll [post]
+func (uc *UserController) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	secret, uri, err := uc.service.EnrollTwoFactor(contextUser)
+	if err != nil {
+		if errors.Is(err, service.ErrTwoFactorEnabled) {
+			exceptions.Conflict(w, err, "Two factor already enabled")
+			return
+		}
+
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot start two factor enrollment", reqId)
+		return
+	}
+
+	response.OK(w, "Confirm a code of the app to enable two factor", response.TwoFactorEnrollment{
+		Secret:     secret,
+		OtpauthURI: uri,
+	})
+}
+
+// ConfirmTwoFactor godoc
+// @Summary Enable two factor authentication
+// @Description Enables two factor with a code of the authenticator app and returns the recovery codes. They are only shown here, each works once.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body request.TwoFactorCode true "Code of the authenticator app"
+// @Success 200 {object} response.TwoFactorRecoveryCodes
+// @Failure 400 {string} string "Invalid code"
+// @Failure 409 {string} string "Two factor already enabled"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /profiles/2fa/confirm [post]
+func (uc *UserController) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var data request.TwoFactorCode
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&data); err != nil {
+		exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+		return
+	}
+
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	codes, err := uc.service.ConfirmTwoFactor(contextUser, strings.TrimSpace(data.Code))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTwoFactorCode):
+			exceptions.BadRequest(w, err, "Invalid code", nil)
+		case errors.Is(err, service.ErrTwoFactorNotEnrolled):
+			exceptions.BadRequest(w, err, "Start the enrollment in /profiles/2fa/enroll first", nil)
+		case errors.Is(err, service.ErrTwoFactorEnabled):
+			exceptions.Conflict(w, err, "Two factor already enabled")
+		default:
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot enable two factor", reqId)
+		}
+		return
+	}
+
+	uc.audit.Record(auditEntry(r, contextUser, enums.AuditTwoFactorEnabled, "user", contextUser.ID.String()))
+
+	response.OK(w, "Two factor enabled, keep the recovery codes safe", response.TwoFactorRecoveryCodes{
+		RecoveryCodes: codes,
+	})
+}
+
+// DisableTwoFactor godoc
+// @Summary Disable two factor authentication
+// @Description Disables two factor, it needs the password and a code (or a recovery code). Authors and admins cannot disable it when the blog requires it.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param request body request.TwoFactorDisable true "Password and code"
+// @Success 200 {object} response.UserLogout
+// @Failure 400 {string} string "Invalid code"
+// @Failure 403 {string} string "Current password is incorrect"
+// @Failure 403 {string} string "Two factor is required for authors and admins"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /profiles/2fa [delete]
+func (uc *UserController) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var data request.TwoFactorDisable
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&data); err != nil {
+		exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+		return
+	}
+
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	if err := uc.service.DisableTwoFactor(contextUser, data.Password, strings.TrimSpace(data.Code)); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidTwoFactorCode):
+			exceptions.BadRequest(w, err, "Invalid code", nil)
+		case errors.Is(err, service.ErrTwoFactorNotEnrolled):
+			exceptions.BadRequest(w, err, "Two factor is not enabled", nil)
+		case errors.Is(err, service.ErrWrongPassword):
+			exceptions.Forbidden(w, err, "Current password is incorrect")
+		case errors.Is(err, service.ErrTwoFactorRequired):
+			exceptions.Forbidden(w, err, "Two factor is required for authors and admins")
+		default:
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot disable two factor", reqId)
+		}
+		return
+	}
+
+	uc.audit.Record(auditEntry(r, contextUser, enums.AuditTwoFactorDisabled, "user", contextUser.ID.String()))
+
+	response.OK(w, "Two factor disabled", response.UserLogout{
+		Message: "two factor disabled with success",
+	})
+}
+
+// LoginTwoFactor godoc
+// @Summary Login second step
+// @Description Completes the login of an account with two factor, with the challenge token returned by /login and a code of the app or a recovery code.
+// @Description Each challenge accepts LOGIN_MAX_2FA_ATTEMPTS codes (5 by default), wrong codes count as failed logins of the account.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.TwoFactorLogin true "Challenge token and code"
+// @Success 200 {object} response.UserLogin
+// @Failure 401 {string} string "Invalid or expired code"
+// @Failure 401 {string} string "Too many wrong codes, login again"
+// @Failure 429 {string} string "Too many failed attempts, see the Retry-After header"
+// @Router /login/2fa [post]
+func (uc *UserController) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var data request.TwoFactorLogin
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&data); err != nil {
+		exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+		return
+	}
+
+	if data.ChallengeToken == "" || data.Code == "" {
+		exceptions.BadRequest(w, errors.New("Request Error"), "Challenge token and code are required", nil)
+		return
+	}
+
+	challenge, user, err := uc.service.ValidateTwoFactorChallenge(data.ChallengeToken)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTwoFactorCode) {
+			exceptions.Unauthorized(w, "Invalid or expired code")
+			return
+		}
+
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot verify the code", reqId)
+		return
+	}
+
+	ip := clientIP(r)
+	if wait, err := uc.guard.Check(user.Email, ip); err != nil {
+		exceptions.TooManyRequests(w, err, "Too many failed attempts, try again later", wait)
+		return
+	}
+
+	if err := uc.guard.UseChallenge(challenge.ID, challenge.ExpiresAt); err != nil {
+		uc.guard.Release(user.Email, ip)
+		exceptions.Unauthorized(w, "Too many wrong codes, login again")
+		return
+	}
+
+	recovery, err := uc.service.VerifyTwoFactor(user, strings.TrimSpace(data.Code))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidTwoFactorCode) {
+			uc.recordLoginFailure(r, user, user.Email, ip)
+			exceptions.Unauthorized(w, "Invalid or expired code")
+			return
+		}
+
+		uc.guard.Release(user.Email, ip)
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot verify the code", reqId)
+		return
+	}
+	uc.guard.SpendChallenge(challenge.ID)
+	uc.guard.RecordSuccess(user.Email, ip)
+
+	if recovery {
+		uc.audit.Record(auditEntry(r, user, enums.AuditRecoveryCodeUsed, "user", user.ID.String()))
+	}
+
+	if err := uc.service.CheckAccountStatus(user); err != nil {
+		exceptions.Forbidden(w, err, "This account is suspended or banned")
+		return
+	}
+
+	tokens, err := uc.sessions.StartSession(user, sessionMeta(r, challenge.Device))
+	if err != nil {
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot generate JWT to this Session", reqId)
+		return
+	}
+	auditLogin(uc.audit, r, user, tokens, "2fa")
+
+	response.OK(w, "User has logged successfully", setSessionCookies(w, tokens))
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+	"github.com/clemilsonazevedo/blog/internal/dto/request"
+	"github.com/clemilsonazevedo/blog/internal/dto/response"
+	"github.com/clemilsonazevedo/blog/internal/service"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// VerifyEmail godoc
+// @Summary Confirm the user email
+// @Description Confirms the email of an account with the token sent by email. Each token can be used once.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body request.VerifyEmail true "Verification token"
+// @Success 200 {object} response.UserProfile
+// @Failure 400 {string} string "Invalid or expired token"
+// @Failure 409 {string} string "Email already verified"
+// @Router /verify-email [post]
+func (uc *UserController) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var data request.VerifyEmail
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&data); err != nil {
+		exceptions.BadRequest(w, err, "Cannot Decode Body", nil)
+		return
+	}
+
+	if data.Token == "" {
+		exceptions.BadRequest(w, errors.New("Request Error"), "The token is required", nil)
+		return
+	}
+
+	user, err := uc.service.VerifyEmail(data.Token)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidVerificationToken):
+			exceptions.BadRequest(w, err, "Invalid or expired token", nil)
+		case errors.Is(err, service.ErrEmailAlreadyVerified):
+			exceptions.Conflict(w, err, "Email already verified")
+		default:
+			reqId := middleware.GetReqID(r.Context())
+			exceptions.InternalError(w, err, "Cannot verify this email", reqId)
+		}
+		return
+	}
+
+	response.OK(w, "Email verified with success", response.UserProfile{
+		ID:       user.ID,
+		UserName: user.UserName,
+		Email:    user.Email,
+		Role:     user.Role,
+
+		EmailVerified: true,
+	})
+}
+
+// ResendVerificationEmail godoc
+// @Summary Resend the confirmation email
+// @Description Sends a new confirmation link to the email of the logged user
+// @Tags Auth
+// @Produce json
+// @Success 202 {object} response.UserResponse
+// @Failure 401 {string} string "unauthorized"
+// @Failure 409 {string} string "Email already verified"
+// @Security CookieAuth
+// @Security BearerAuth
+// @Router /verify-email/resend [post]
+func (uc *UserController) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	contextUser, ok := r.Context().Value("user").(*entities.User)
+	if !ok {
+		exceptions.Unauthorized(w, "unauthorized")
+		return
+	}
+
+	if err := uc.service.SendVerificationEmail(contextUser); err != nil {
+		if errors.Is(err, service.ErrEmailAlreadyVerified) {
+			exceptions.Conflict(w, err, "Email already verified")
+			return
+		}
+
+		reqId := middleware.GetReqID(r.Context())
+		exceptions.InternalError(w, err, "Cannot send the verification email", reqId)
+		return
+	}
+
+	response.WriteJSON(w, http.StatusAccepted, response.UserResponse{
+		Message:   "verification email sent",
+		UserID:    contextUser.ID.String(),
+		Timestamp: time.Now().UTC(),
+	})
+}
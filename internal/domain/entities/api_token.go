@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+type ApiScope = enums.ApiScope
+
+// ApiToken is a long lived credential for automation (CI pipelines...). Only
+// the hash of the secret is stored, Prefix is the public part shown to the
+// user to recognize the token and used to find it.
+type ApiToken struct {
+	ID pkg.ULID `gorm:"column:id;primaryKey;type:varchar(26);not null" json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+
+	UserID     pkg.ULID `gorm:"column:user_id;type:varchar(26);index;not null" json:"user_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Name       string   `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	Prefix     string   `gorm:"column:prefix;type:varchar(20);uniqueIndex;not null" json:"prefix"`
+	SecretHash string   `gorm:"column:secret_hash;type:varchar(64);not null" json:"-"`
+	// Scopes is a comma separated list, see enums.ApiScope
+	Scopes string `gorm:"column:scopes;type:text;not null" json:"scopes"`
+
+	ExpiresAt  *time.Time `gorm:"column:expires_at" json:"expires_at"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at" json:"last_used_at"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at" json:"revoked_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at;not null;autoCreateTime" json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+func (ApiToken) TableName() string {
+	return "api_tokens"
+}
+
+func (token ApiToken) GetID() any {
+	return token.ID
+}
+
+func (token ApiToken) ScopeList() []ApiScope {
+	scopes := []ApiScope{}
+	for scope := range strings.SplitSeq(token.Scopes, ",") {
+		if scope != "" {
+			scopes = append(scopes, ApiScope(scope))
+		}
+	}
+	return scopes
+}
+
+func (token ApiToken) HasScope(scope ApiScope) bool {
+	return slices.Contains(token.ScopeList(), scope)
+}
+
+func (token *ApiToken) SetScopes(scopes []ApiScope) {
+	names := make([]string, len(scopes))
+	for i, scope := range scopes {
+		names[i] = string(scope)
+	}
+	token.Scopes = strings.Join(names, ",")
+}
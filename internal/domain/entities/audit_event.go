@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+type AuditAction = enums.AuditAction
+
+// AuditEvent is an append only record of a sensitive action. ActorID is nil
+// when nobody was logged in (a failed login, a password reset...).
+type AuditEvent struct {
+	ID pkg.ULID `gorm:"column:id;primaryKey;type:varchar(26);not null" json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+
+	ActorID    *pkg.ULID   `gorm:"column:actor_id;type:varchar(26);index" json:"actor_id,omitempty" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Action     AuditAction `gorm:"column:action;type:varchar(64);index;not null" json:"action" swaggertype:"string"`
+	TargetType string      `gorm:"column:target_type;type:varchar(32);index:idx_audit_events_target" json:"target_type"`
+	TargetID   string      `gorm:"column:target_id;type:varchar(64);index:idx_audit_events_target" json:"target_id"`
+
+	IP        string `gorm:"column:ip;type:varchar(45)" json:"ip"`
+	UserAgent string `gorm:"column:user_agent;type:text" json:"user_agent"`
+	RequestID string `gorm:"column:request_id;type:varchar(64)" json:"request_id"`
+	Metadata  string `gorm:"column:metadata;type:jsonb;not null;default:'{}'" json:"metadata" swaggertype:"object"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime;index" json:"created_at"`
+}
+
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
+
+func (event AuditEvent) GetID() any {
+	return event.ID
+}
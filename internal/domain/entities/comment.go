@@ -3,9 +3,12 @@ package entities
 import (
 	"time"
 
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
 	"github.com/clemilsonazevedo/blog/pkg"
 )
 
+type CommentStatus = enums.CommentStatus
+
 type Comment struct {
 	ID        pkg.ULID  `gorm:"column:id;primaryKey;type:varchar(26);not null" json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
 	Content   string    `gorm:"type:text;not null" json:"content"`
@@ -13,10 +16,21 @@ type Comment struct {
 	UserID    pkg.ULID  `gorm:"column:user_id;type:varchar(26);not null" json:"user_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
 	PostID    pkg.ULID  `gorm:"column:post_id;type:varchar(26);index;not null" json:"post_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
 
-	User User `gorm:"foreignKey:UserID;references:ID" json:"user,omitempty"`
-	Post Post `gorm:"foreignKey:PostID;references:ID" json:"post,omitempty"`
+	ParentID  *pkg.ULID `gorm:"column:parent_id;type:varchar(26);index" json:"parent_id,omitempty" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Depth     int       `gorm:"column:depth;not null;default:0" json:"depth"`
+	IsDeleted bool      `gorm:"column:is_deleted;not null;default:false" json:"is_deleted"`
+
+	EditedAt *time.Time `gorm:"column:edited_at" json:"edited_at,omitempty"`
+
+	Status CommentStatus `gorm:"column:status;type:varchar(20);index;not null;default:'approved'" json:"status" swaggertype:"string" enums:"pending,approved,rejected"`
+
+	User   User     `gorm:"foreignKey:UserID;references:ID" json:"user,omitempty"`
+	Post   Post     `gorm:"foreignKey:PostID;references:ID" json:"post,omitempty"`
+	Parent *Comment `gorm:"foreignKey:ParentID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
 }
 
+const DeletedCommentContent = "[deleted]"
+
 func (Comment) TableName() string {
 	return "comments"
 }
@@ -24,3 +38,7 @@ func (Comment) TableName() string {
 func (comment Comment) GetID() any {
 	return comment.ID
 }
+
+func (comment Comment) OwnerID() pkg.ULID {
+	return comment.UserID
+}
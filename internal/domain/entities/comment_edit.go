@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+type CommentEdit struct {
+	ID pkg.ULID `gorm:"column:id;primaryKey;type:varchar(26);not null" json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+
+	CommentID       pkg.ULID `gorm:"column:comment_id;type:varchar(26);index;not null" json:"comment_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	PreviousContent string   `gorm:"column:previous_content;type:text;not null" json:"previous_content"`
+	EditedBy        pkg.ULID `gorm:"column:edited_by;type:varchar(26);not null" json:"edited_by" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime" json:"created_at"`
+
+	Comment Comment `gorm:"foreignKey:CommentID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+func (CommentEdit) TableName() string {
+	return "comment_edits"
+}
+
+func (edit CommentEdit) GetID() any {
+	return edit.ID
+}
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+// CommentModeration records a comment removed by someone other than its
+// owner. It keeps a copy of the content because the comment itself is gone.
+type CommentModeration struct {
+	ID pkg.ULID `gorm:"column:id;primaryKey;type:varchar(26);not null" json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+
+	CommentID       pkg.ULID `gorm:"column:comment_id;type:varchar(26);index;not null" json:"comment_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	PostID          pkg.ULID `gorm:"column:post_id;type:varchar(26);index;not null" json:"post_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	CommentAuthorID pkg.ULID `gorm:"column:comment_author_id;type:varchar(26);not null" json:"comment_author_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	ModeratorID     pkg.ULID `gorm:"column:moderator_id;type:varchar(26);index;not null" json:"moderator_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	ModeratorRole   Role     `gorm:"column:moderator_role;type:varchar(20);not null" json:"moderator_role"`
+
+	Action  string `gorm:"column:action;type:varchar(20);not null" json:"action"`
+	Reason  string `gorm:"column:reason;type:text;not null" json:"reason"`
+	Content string `gorm:"column:content;type:text;not null" json:"content"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime" json:"created_at"`
+}
+
+func (CommentModeration) TableName() string {
+	return "comment_moderations"
+}
+
+func (moderation CommentModeration) GetID() any {
+	return moderation.ID
+}
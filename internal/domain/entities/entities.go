@@ -5,5 +5,18 @@ func RetrieveAll() []any {
 		&User{},
 		&Post{},
 		&Comment{},
+		&Tag{},
+		&PostSlugHistory{},
+		&PostRevision{},
+		&PostReaction{},
+		&CommentEdit{},
+		&CommentModeration{},
+		&PasswordResetToken{},
+		&AuditEvent{},
+		&Session{},
+		&ApiToken{},
+		&RecoveryCode{},
+		&UserIdentity{},
+		&RateLimitBucket{},
 	}
 }
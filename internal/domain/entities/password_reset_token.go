@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+// PasswordResetToken only keeps the hash of the token sent by email, a
+// leaked table cannot be used to reset passwords.
+type PasswordResetToken struct {
+	ID pkg.ULID `gorm:"column:id;primaryKey;type:varchar(26);not null" json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+
+	UserID    pkg.ULID   `gorm:"column:user_id;type:varchar(26);index;not null" json:"user_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	TokenHash string     `gorm:"column:token_hash;type:varchar(64);uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null" json:"expires_at"`
+	UsedAt    *time.Time `gorm:"column:used_at" json:"used_at"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime" json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
+
+func (token PasswordResetToken) GetID() any {
+	return token.ID
+}
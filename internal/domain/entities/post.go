@@ -3,9 +3,13 @@ package entities
 import (
 	"time"
 
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
 	"github.com/clemilsonazevedo/blog/pkg"
 )
 
+type PostStatus = enums.PostStatus
+type Language = enums.Language
+
 type Post struct {
 	ID pkg.ULID `gorm:"column:id;primaryKey;type:varchar(26);not null" json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
 
@@ -13,14 +17,24 @@ type Post struct {
 	Slug    string `gorm:"column:slug;uniqueIndex:idx_posts_slug;not null;size:300" json:"slug"`
 	Content string `gorm:"column:content;not null" json:"content"`
 
+	Language Language `gorm:"column:language;type:varchar(5);not null;default:'pt-br'" json:"language" swaggertype:"string" enums:"pt-br,en"`
+
 	Likes    int `gorm:"column:likes;not null;default:0" json:"likes"`
 	Views    int `gorm:"column:views;not null;default:0" json:"views"`
 	Dislikes int `gorm:"column:dislikes;not null;default:0" json:"dislikes"`
 
+	Status       PostStatus `gorm:"column:status;type:varchar(20);index;not null;default:'published'" json:"status" swaggertype:"string" enums:"draft,scheduled,published,archived"`
+	PublishedAt  *time.Time `gorm:"column:published_at" json:"published_at,omitempty"`
+	ScheduledFor *time.Time `gorm:"column:scheduled_for;index" json:"scheduled_for,omitempty"`
+
+	RequireCommentApproval bool `gorm:"column:require_comment_approval;not null;default:false" json:"require_comment_approval"`
+
 	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime" json:"created_at"`
 
 	AuthorId pkg.ULID `gorm:"column:author_id;type:varchar(26);index;not null" json:"author_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
 	Author   User     `gorm:"foreignKey:AuthorID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT" json:"-"`
+
+	Tags []Tag `gorm:"many2many:post_tags;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"tags,omitempty"`
 }
 
 func (Post) TableName() string {
@@ -30,3 +44,7 @@ func (Post) TableName() string {
 func (post Post) GetID() any {
 	return post.ID
 }
+
+func (post Post) OwnerID() pkg.ULID {
+	return post.AuthorId
+}
@@ -0,0 +1,27 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+type Reaction = enums.Reaction
+
+type PostReaction struct {
+	UserID pkg.ULID `gorm:"column:user_id;primaryKey;type:varchar(26);not null" json:"user_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	PostID pkg.ULID `gorm:"column:post_id;primaryKey;type:varchar(26);index;not null" json:"post_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+
+	Reaction Reaction `gorm:"column:reaction;type:varchar(10);not null" json:"reaction" swaggertype:"string" enums:"like,dislike"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null;autoUpdateTime" json:"updated_at"`
+
+	User User `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	Post Post `gorm:"foreignKey:PostID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+func (PostReaction) TableName() string {
+	return "post_reactions"
+}
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+type PostRevision struct {
+	ID pkg.ULID `gorm:"column:id;primaryKey;type:varchar(26);not null" json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+
+	PostID   pkg.ULID `gorm:"column:post_id;type:varchar(26);uniqueIndex:idx_post_revisions_post_revision;not null" json:"post_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Revision int      `gorm:"column:revision;uniqueIndex:idx_post_revisions_post_revision;not null" json:"revision"`
+
+	Title   string `gorm:"column:title;not null" json:"title"`
+	Slug    string `gorm:"column:slug;not null;size:300" json:"slug"`
+	Content string `gorm:"column:content;not null" json:"content"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime" json:"created_at"`
+
+	Post Post `gorm:"foreignKey:PostID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+func (PostRevision) TableName() string {
+	return "post_revisions"
+}
+
+func (revision PostRevision) GetID() any {
+	return revision.ID
+}
@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+type PostSlugHistory struct {
+	ID pkg.ULID `gorm:"column:id;primaryKey;type:varchar(26);not null" json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+
+	Slug   string   `gorm:"column:slug;uniqueIndex:idx_post_slug_history_slug;not null;size:300" json:"slug"`
+	PostID pkg.ULID `gorm:"column:post_id;type:varchar(26);index;not null" json:"post_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime" json:"created_at"`
+
+	Post Post `gorm:"foreignKey:PostID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+func (PostSlugHistory) TableName() string {
+	return "post_slug_history"
+}
+
+func (history PostSlugHistory) GetID() any {
+	return history.ID
+}
@@ -0,0 +1,21 @@
+package entities
+
+import "time"
+
+// RateLimitBucket is a token bucket of the rate limiter when the buckets are
+// shared by several instances of the API. FullAt is when the bucket refills
+// completely, after that it can be deleted.
+type RateLimitBucket struct {
+	Key        string    `gorm:"column:key;primaryKey;type:varchar(191);not null" json:"key"`
+	Tokens     float64   `gorm:"column:tokens;not null" json:"tokens"`
+	RefilledAt time.Time `gorm:"column:refilled_at;not null" json:"refilled_at"`
+	FullAt     time.Time `gorm:"column:full_at;not null;index" json:"full_at"`
+}
+
+func (RateLimitBucket) TableName() string {
+	return "rate_limit_buckets"
+}
+
+func (bucket RateLimitBucket) GetID() any {
+	return bucket.Key
+}
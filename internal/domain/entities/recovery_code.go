@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+// RecoveryCode lets a user login without the authenticator app, each code
+// works once. Only the hash is stored.
+type RecoveryCode struct {
+	ID pkg.ULID `gorm:"column:id;primaryKey;type:varchar(26);not null" json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+
+	UserID   pkg.ULID   `gorm:"column:user_id;type:varchar(26);uniqueIndex:idx_recovery_codes_user_code;not null" json:"user_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	CodeHash string     `gorm:"column:code_hash;type:varchar(64);uniqueIndex:idx_recovery_codes_user_code;not null" json:"-"`
+	UsedAt   *time.Time `gorm:"column:used_at" json:"used_at"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime" json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+func (RecoveryCode) TableName() string {
+	return "recovery_codes"
+}
+
+func (code RecoveryCode) GetID() any {
+	return code.ID
+}
@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+// Session is one refresh token. Every refresh rotates the token: the row is
+// marked as rotated and a new one is created in the same family. The family
+// is what the user sees as a session (one per login/device), its ID is the
+// "sid" claim of the access tokens.
+type Session struct {
+	ID pkg.ULID `gorm:"column:id;primaryKey;type:varchar(26);not null" json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+
+	FamilyID  pkg.ULID `gorm:"column:family_id;type:varchar(26);index;not null" json:"family_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	UserID    pkg.ULID `gorm:"column:user_id;type:varchar(26);index;not null" json:"user_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	TokenHash string   `gorm:"column:token_hash;type:varchar(64);uniqueIndex;not null" json:"-"`
+
+	Device    string `gorm:"column:device;type:varchar(100)" json:"device"`
+	IP        string `gorm:"column:ip;type:varchar(45)" json:"ip"`
+	UserAgent string `gorm:"column:user_agent;type:text" json:"user_agent"`
+
+	// SignedInAt is the login that started the family
+	SignedInAt time.Time  `gorm:"column:signed_in_at;not null" json:"signed_in_at"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at;not null" json:"expires_at"`
+	RotatedAt  *time.Time `gorm:"column:rotated_at" json:"rotated_at"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at" json:"revoked_at"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime" json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+func (Session) TableName() string {
+	return "sessions"
+}
+
+func (session Session) GetID() any {
+	return session.ID
+}
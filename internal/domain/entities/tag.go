@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+type Tag struct {
+	ID pkg.ULID `gorm:"column:id;primaryKey;type:varchar(26);not null" json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+
+	Name string `gorm:"column:name;not null;size:100" json:"name"`
+	Slug string `gorm:"column:slug;uniqueIndex:idx_tags_slug;not null;size:120" json:"slug"`
+
+	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime" json:"created_at"`
+
+	Posts []Post `gorm:"many2many:post_tags;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+func (Tag) TableName() string {
+	return "tags"
+}
+
+func (tag Tag) GetID() any {
+	return tag.ID
+}
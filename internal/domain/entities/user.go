@@ -5,7 +5,6 @@ import (
 
 	"github.com/clemilsonazevedo/blog/internal/domain/enums"
 	"github.com/clemilsonazevedo/blog/pkg"
-	"golang.org/x/crypto/bcrypt"
 )
 
 type Role = enums.Role
@@ -16,9 +15,26 @@ type User struct {
 	UserName  string    `gorm:"column:username;unique;not null" json:"username"`
 	Email     string    `gorm:"column:email;unique;not null" json:"email"`
 	Password  string    `gorm:"column:password;not null" json:"password"`
-	Role      Role      `gorm:"type:user_role;default:'reader'" json:"role" swaggertype:"string" enums:"anonymous,reader,author"`
+	Role      Role      `gorm:"type:user_role;default:'reader'" json:"role" swaggertype:"string" enums:"anonymous,reader,author,moderator,admin"`
 	CreatedAt time.Time `gorm:"column:created_at;not null;autoCreateTime" json:"created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at;not null;autoUpdateTime" json:"updated_at"`
+
+	EmailVerifiedAt *time.Time `gorm:"column:email_verified_at" json:"email_verified_at"`
+	// Session tokens issued before this date are no longer accepted
+	SessionsRevokedAt *time.Time `gorm:"column:sessions_revoked_at" json:"-"`
+
+	// TOTPSecret is set on enrollment, two factor is only enabled once the
+	// first code is confirmed. TOTPLastStep is the last step used to login,
+	// a code is never accepted twice.
+	TOTPSecret         string     `gorm:"column:totp_secret;type:varchar(64)" json:"-"`
+	TOTPLastStep       int64      `gorm:"column:totp_last_step;not null;default:0" json:"-"`
+	TwoFactorEnabledAt *time.Time `gorm:"column:two_factor_enabled_at" json:"-"`
+
+	// A suspended user cannot login until SuspendedUntil, a banned one never
+	// again. StatusReason is the reason given by the admin.
+	SuspendedUntil *time.Time `gorm:"column:suspended_until" json:"suspended_until"`
+	BannedAt       *time.Time `gorm:"column:banned_at" json:"banned_at"`
+	StatusReason   string     `gorm:"column:status_reason" json:"status_reason"`
 }
 
 func (User) TableName() string {
@@ -29,18 +45,22 @@ func (user User) GetID() any {
 	return user.ID
 }
 
-func HashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword(
-		[]byte(password),
-		14,
-	)
-	return string(bytes), err
+func (user User) OwnerID() pkg.ULID {
+	return user.ID
+}
+
+func (user User) IsEmailVerified() bool {
+	return user.EmailVerifiedAt != nil
+}
+
+func (user User) IsTwoFactorEnabled() bool {
+	return user.TwoFactorEnabledAt != nil
+}
+
+func (user User) IsBanned() bool {
+	return user.BannedAt != nil
 }
 
-func CheckPasswordHash(hash, password string) bool {
-	err := bcrypt.CompareHashAndPassword(
-		[]byte(hash),
-		[]byte(password),
-	)
-	return err == nil
+func (user User) IsSuspended(now time.Time) bool {
+	return user.SuspendedUntil != nil && user.SuspendedUntil.After(now)
 }
@@ -0,0 +1,33 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+// UserIdentity links a user to an account on an external OpenID Connect
+// provider. The issuer and subject identify the account, the email is the
+// one the provider had on the last login.
+type UserIdentity struct {
+	ID pkg.ULID `gorm:"column:id;primaryKey;type:varchar(26);not null" json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+
+	UserID   pkg.ULID `gorm:"column:user_id;type:varchar(26);index;not null" json:"user_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Provider string   `gorm:"column:provider;type:varchar(50);not null" json:"provider"`
+	Issuer   string   `gorm:"column:issuer;uniqueIndex:idx_user_identities_issuer_subject;not null" json:"issuer"`
+	Subject  string   `gorm:"column:subject;uniqueIndex:idx_user_identities_issuer_subject;not null" json:"subject"`
+	Email    string   `gorm:"column:email" json:"email"`
+
+	CreatedAt   time.Time  `gorm:"column:created_at;not null;autoCreateTime" json:"created_at"`
+	LastLoginAt *time.Time `gorm:"column:last_login_at" json:"last_login_at"`
+
+	User User `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+}
+
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
+
+func (identity UserIdentity) GetID() any {
+	return identity.ID
+}
@@ -0,0 +1,20 @@
+package enums
+
+// ApiScope limits what an API token can do, sessions are not limited by
+// scopes.
+type ApiScope string
+
+const (
+	ScopePostsRead     ApiScope = "posts:read"
+	ScopePostsWrite    ApiScope = "posts:write"
+	ScopeCommentsRead  ApiScope = "comments:read"
+	ScopeCommentsWrite ApiScope = "comments:write"
+)
+
+func (s ApiScope) IsValid() bool {
+	switch s {
+	case ScopePostsRead, ScopePostsWrite, ScopeCommentsRead, ScopeCommentsWrite:
+		return true
+	}
+	return false
+}
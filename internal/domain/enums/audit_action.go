@@ -0,0 +1,41 @@
+package enums
+
+type AuditAction string
+
+const (
+	AuditPasswordChanged      AuditAction = "user.password.change"
+	AuditEmailChangeRequested AuditAction = "user.email.change_request"
+	AuditEmailChanged         AuditAction = "user.email.change"
+	AuditTwoFactorEnabled     AuditAction = "user.2fa.enable"
+	AuditTwoFactorDisabled    AuditAction = "user.2fa.disable"
+	AuditRecoveryCodeUsed     AuditAction = "user.2fa.recovery_code"
+	AuditIdentityLinked       AuditAction = "user.identity.link"
+	AuditIdentityRegistered   AuditAction = "user.identity.register"
+
+	AuditRoleChanged     AuditAction = "user.role.change"
+	AuditUserSuspended   AuditAction = "user.suspend"
+	AuditUserBanned      AuditAction = "user.ban"
+	AuditUserReactivated AuditAction = "user.reactivate"
+	AuditForcedLogout    AuditAction = "user.force_logout"
+
+	AuditLogin        AuditAction = "auth.login"
+	AuditLoginFailed  AuditAction = "auth.login.fail"
+	AuditLoginLockout AuditAction = "auth.login.lockout"
+	AuditLogout       AuditAction = "auth.logout"
+
+	AuditSessionRevoked     AuditAction = "session.revoke"
+	AuditRefreshTokenReused AuditAction = "session.refresh_reuse"
+
+	AuditApiTokenCreated AuditAction = "api_token.create"
+	AuditApiTokenRevoked AuditAction = "api_token.revoke"
+
+	AuditPostCreated       AuditAction = "post.create"
+	AuditPostUpdated       AuditAction = "post.update"
+	AuditPostStatusChanged AuditAction = "post.status.change"
+	AuditPostDeleted       AuditAction = "post.delete"
+
+	AuditCommentCreated   AuditAction = "comment.create"
+	AuditCommentUpdated   AuditAction = "comment.update"
+	AuditCommentModerated AuditAction = "comment.moderate"
+	AuditCommentDeleted   AuditAction = "comment.delete"
+)
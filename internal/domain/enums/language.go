@@ -0,0 +1,30 @@
+package enums
+
+import "fmt"
+
+type Language string
+
+const (
+	Portuguese Language = "pt-br"
+	English    Language = "en"
+)
+
+func (l Language) IsValid() bool {
+	return l == Portuguese || l == English
+}
+
+// SearchConfig is the PostgreSQL text search configuration used to index
+// and query content written in this language.
+func (l Language) SearchConfig() string {
+	if l == English {
+		return "english"
+	}
+	return "portuguese"
+}
+
+// SearchConfigSQL is the SQL expression picking the text search
+// configuration of a row from its language column.
+func SearchConfigSQL(column string) string {
+	return fmt.Sprintf("CASE WHEN %s = '%s' THEN '%s'::regconfig ELSE '%s'::regconfig END",
+		column, English, English.SearchConfig(), Portuguese.SearchConfig())
+}
@@ -0,0 +1,18 @@
+package enums
+
+type PostStatus string
+
+const (
+	Draft     PostStatus = "draft"
+	Scheduled PostStatus = "scheduled"
+	Published PostStatus = "published"
+	Archived  PostStatus = "archived"
+)
+
+func (s PostStatus) IsValid() bool {
+	switch s {
+	case Draft, Scheduled, Published, Archived:
+		return true
+	}
+	return false
+}
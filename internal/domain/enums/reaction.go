@@ -0,0 +1,12 @@
+package enums
+
+type Reaction string
+
+const (
+	Like    Reaction = "like"
+	Dislike Reaction = "dislike"
+)
+
+func (r Reaction) IsValid() bool {
+	return r == Like || r == Dislike
+}
@@ -6,4 +6,27 @@ const (
 	Anonymous Role = "anonymous"
 	Reader    Role = "reader"
 	Author    Role = "author"
+	Moderator Role = "moderator"
+	Admin     Role = "admin"
 )
+
+func (r Role) IsValid() bool {
+	switch r {
+	case Anonymous, Reader, Author, Moderator, Admin:
+		return true
+	}
+	return false
+}
+
+// UserStatus filters users by their suspension or ban.
+type UserStatus string
+
+const (
+	UserActive    UserStatus = "active"
+	UserSuspended UserStatus = "suspended"
+	UserBanned    UserStatus = "banned"
+)
+
+func (s UserStatus) IsValid() bool {
+	return s == UserActive || s == UserSuspended || s == UserBanned
+}
@@ -2,7 +2,9 @@ package exceptions
 
 import (
 	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/clemilsonazevedo/blog/internal/dto/response"
@@ -35,6 +37,15 @@ func Unauthorized(w http.ResponseWriter, message string) {
 	response.WriteJSON(w, http.StatusUnauthorized, resp)
 }
 
+func Forbidden(w http.ResponseWriter, err error, message string) {
+	resp := ErrorResponse{
+		Error:     err.Error(),
+		Message:   message,
+		Timestamp: time.Now().UTC(),
+	}
+	response.WriteJSON(w, http.StatusForbidden, resp)
+}
+
 func NotFound(w http.ResponseWriter, err error, message string) {
 	resp := ErrorResponse{
 		Error:     err.Error(),
@@ -64,3 +75,16 @@ func InternalError(w http.ResponseWriter, err error, message string, correlation
 	}
 	response.WriteJSON(w, http.StatusInternalServerError, resp)
 }
+
+// TooManyRequests tells the client to wait, Retry-After is in whole seconds.
+func TooManyRequests(w http.ResponseWriter, err error, message string, retryAfter time.Duration) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(max(seconds, 1)))
+
+	resp := ErrorResponse{
+		Error:     err.Error(),
+		Message:   message,
+		Timestamp: time.Now().UTC(),
+	}
+	response.WriteJSON(w, http.StatusTooManyRequests, resp)
+}
@@ -0,0 +1,20 @@
+package request
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+)
+
+type UserRoleChange struct {
+	Role enums.Role `json:"role" binding:"required,oneof=reader author moderator admin" swaggertype:"string" enums:"reader,author,moderator,admin"`
+}
+
+type UserSuspend struct {
+	Until  time.Time `json:"until" binding:"required" example:"2026-01-01T00:00:00Z"`
+	Reason string    `json:"reason" binding:"omitempty,max=500"`
+}
+
+type UserBan struct {
+	Reason string `json:"reason" binding:"omitempty,max=500"`
+}
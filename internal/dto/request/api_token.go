@@ -0,0 +1,13 @@
+package request
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+)
+
+type ApiTokenCreate struct {
+	Name      string           `json:"name" binding:"required,max=100" example:"CI publisher"`
+	Scopes    []enums.ApiScope `json:"scopes" binding:"required,min=1" swaggertype:"array,string" example:"posts:write"`
+	ExpiresAt *time.Time       `json:"expires_at,omitempty"`
+}
@@ -3,9 +3,10 @@ package request
 import "github.com/clemilsonazevedo/blog/pkg"
 
 type CommentCreate struct {
-	UserID  pkg.ULID `json:"userId" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
-	PostID  pkg.ULID `json:"postId" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
-	Content string   `json:"content"`
+	UserID   pkg.ULID  `json:"userId" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	PostID   pkg.ULID  `json:"postId" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	ParentID *pkg.ULID `json:"parentId,omitempty" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Content  string    `json:"content"`
 }
 
 type CommentUpdate struct {
@@ -16,5 +17,14 @@ type CommentUpdate struct {
 }
 
 type CommentDelete struct {
-	ID pkg.ULID `json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	ID     pkg.ULID `json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Reason string   `json:"reason" example:"Spam"`
+}
+
+type CommentReview struct {
+	Reason string `json:"reason" example:"Off topic"`
+}
+
+type PostCommentApproval struct {
+	Required bool `json:"required"`
 }
@@ -1,22 +1,44 @@
 package request
 
-import "github.com/clemilsonazevedo/blog/pkg"
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/pkg"
+)
 
 type PostCreate struct {
 	Title    string   `json:"title" binding:"required,min=2,max=100"`
 	Content  string   `json:"content" binding:"required,min=2,max=1000"`
 	AuthorId pkg.ULID `json:"author_id" binding:"required,min=1" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Tags     []string `json:"tags" binding:"omitempty,max=10" example:"go,backend"`
+
+	Language enums.Language `json:"language" binding:"omitempty,oneof=pt-br en" swaggertype:"string" enums:"pt-br,en"`
+
+	Status       enums.PostStatus `json:"status" binding:"omitempty,oneof=draft scheduled published" swaggertype:"string" enums:"draft,scheduled,published"`
+	ScheduledFor *time.Time       `json:"scheduled_for" binding:"omitempty"`
 }
 
 type AiPostCreate struct {
 	Content  string   `json:"content" binding:"required,min=2,max=1000"`
 	AuthorId pkg.ULID `json:"author_id" binding:"required,min=1" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Tags     []string `json:"tags" binding:"omitempty,max=10" example:"go,backend"`
+
+	Status       enums.PostStatus `json:"status" binding:"omitempty,oneof=draft scheduled published" swaggertype:"string" enums:"draft,scheduled,published"`
+	ScheduledFor *time.Time       `json:"scheduled_for" binding:"omitempty"`
 }
 
 type PostUpdate struct {
 	Title    string   `json:"title" binding:"required,min=2,max=100"`
 	AuthorId pkg.ULID `json:"author_id" binding:"required,min=1" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
 	Content  string   `json:"content" binding:"required,min=2,max=1000"`
+	Tags     []string `json:"tags" binding:"omitempty,max=10" example:"go,backend"`
+
+	Language enums.Language `json:"language" binding:"omitempty,oneof=pt-br en" swaggertype:"string" enums:"pt-br,en"`
+}
+
+type PostPublish struct {
+	ScheduledFor *time.Time `json:"scheduled_for" binding:"omitempty"`
 }
 
 type PostDelete struct {
@@ -0,0 +1,7 @@
+package request
+
+import "github.com/clemilsonazevedo/blog/internal/domain/enums"
+
+type PostReaction struct {
+	Reaction enums.Reaction `json:"reaction" binding:"required,oneof=like dislike" swaggertype:"string" enums:"like,dislike"`
+}
@@ -14,15 +14,63 @@ type UserRegister struct {
 type UserLogin struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=8,max=100"`
+	// Device is an optional name to recognize the session, like "Work laptop"
+	Device string `json:"device,omitempty" binding:"omitempty,max=100"`
 }
 
 type UserUpdate struct {
 	ID       pkg.ULID   `json:"id" binding:"required,min=1" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
 	UserName string     `json:"username" binding:"omitempty,min=2,max=100"`
 	Email    string     `json:"email" binding:"omitempty,email"`
-	Role     enums.Role `json:"role" binding:"required,oneof=anonymous reader author" swaggertype:"string" enums:"anonymous,reader,author"`
+	Role     enums.Role `json:"role" binding:"required,oneof=anonymous reader author moderator admin" swaggertype:"string" enums:"anonymous,reader,author,moderator,admin"`
 }
 
 type UserDelete struct {
 	ID int `json:"id" binding:"required"`
 }
+
+type VerifyEmail struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type PasswordForgot struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type PasswordReset struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8,max=100"`
+}
+
+type PasswordChange struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8,max=100"`
+}
+
+type EmailChange struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required"`
+}
+
+type EmailChangeConfirm struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type SessionRefresh struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type TwoFactorCode struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+type TwoFactorDisable struct {
+	Password string `json:"password" binding:"required"`
+	Code     string `json:"code" binding:"required" example:"123456"`
+}
+
+type TwoFactorLogin struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	// Code is a code of the authenticator app or a recovery code
+	Code string `json:"code" binding:"required" example:"123456"`
+}
@@ -0,0 +1,24 @@
+package response
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+// AdminUserResponse is a user as seen by the admins, with its account status.
+type AdminUserResponse struct {
+	ID       pkg.ULID   `json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	UserName string     `json:"username"`
+	Email    string     `json:"email"`
+	Role     enums.Role `json:"role" swaggertype:"string" enums:"anonymous,reader,author,moderator,admin"`
+
+	EmailVerified    bool `json:"email_verified"`
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
+
+	SuspendedUntil *time.Time `json:"suspended_until"`
+	BannedAt       *time.Time `json:"banned_at"`
+	StatusReason   string     `json:"status_reason,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
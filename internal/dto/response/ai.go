@@ -3,4 +3,5 @@ package response
 type AiResponse struct {
 	Title    string   `json:"title"`
 	Hashtags []string `json:"hashtags"`
+	Language string   `json:"language"`
 }
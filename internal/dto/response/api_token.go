@@ -0,0 +1,24 @@
+package response
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+type ApiTokenResponse struct {
+	ID         pkg.ULID         `json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Name       string           `json:"name"`
+	Prefix     string           `json:"prefix" example:"blog_k3v9x0qa"`
+	Scopes     []enums.ApiScope `json:"scopes" swaggertype:"array,string" example:"posts:write"`
+	ExpiresAt  *time.Time       `json:"expires_at"`
+	LastUsedAt *time.Time       `json:"last_used_at"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// CreatedApiTokenResponse is the only response with the secret of the token.
+type CreatedApiTokenResponse struct {
+	ApiTokenResponse
+	Token string `json:"token" example:"blog_k3v9x0qa_Xy3..."`
+}
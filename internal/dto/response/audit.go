@@ -0,0 +1,30 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+type AuditEventResponse struct {
+	ID         pkg.ULID          `json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	ActorID    *pkg.ULID         `json:"actor_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Action     enums.AuditAction `json:"action" swaggertype:"string" example:"user.role.change"`
+	TargetType string            `json:"target_type"`
+	TargetID   string            `json:"target_id"`
+
+	IP        string          `json:"ip"`
+	UserAgent string          `json:"user_agent"`
+	RequestID string          `json:"request_id"`
+	Metadata  json.RawMessage `json:"metadata" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// AuditEventsPage is a page of the audit log, NextCursor is empty on the
+// last page.
+type AuditEventsPage struct {
+	Events     []AuditEventResponse `json:"events"`
+	NextCursor string               `json:"next_cursor"`
+}
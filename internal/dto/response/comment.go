@@ -4,13 +4,15 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
 	"github.com/clemilsonazevedo/blog/pkg"
 )
 
 type CreatedCommentResponse struct {
-	Message   string    `json:"message"`
-	CommentId pkg.ULID  `json:"comment_id"`
-	Timestamp time.Time `json:"timestamp"`
+	Message   string              `json:"message"`
+	CommentId pkg.ULID            `json:"comment_id"`
+	Status    enums.CommentStatus `json:"status" swaggertype:"string" enums:"pending,approved"`
+	Timestamp time.Time           `json:"timestamp"`
 }
 
 type DeletedCommentResponse struct {
@@ -25,6 +27,22 @@ type CommentResponse struct {
 	PostID    pkg.ULID  `json:"post_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
 	Content   string    `json:"content"`
 	CreatedAt time.Time `json:"created_at"`
+
+	ParentID   *pkg.ULID           `json:"parent_id,omitempty" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Depth      int                 `json:"depth"`
+	ReplyCount int                 `json:"reply_count"`
+	IsDeleted  bool                `json:"is_deleted"`
+	EditedAt   *time.Time          `json:"edited_at,omitempty"`
+	Status     enums.CommentStatus `json:"status" swaggertype:"string" enums:"pending,approved,rejected"`
+	Replies    []CommentResponse   `json:"replies,omitempty"`
+}
+
+type CommentEditResponse struct {
+	ID              pkg.ULID  `json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	CommentID       pkg.ULID  `json:"comment_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	PreviousContent string    `json:"previous_content"`
+	EditedBy        pkg.ULID  `json:"edited_by" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	CreatedAt       time.Time `json:"created_at"`
 }
 
 type ShowCommentResponse struct {
@@ -36,10 +54,16 @@ func ShowComments(w http.ResponseWriter, commentsObj any) {
 	OK(w, "success", commentsObj)
 }
 
-func CreatedComment(w http.ResponseWriter, commentId pkg.ULID) {
+func CreatedComment(w http.ResponseWriter, commentId pkg.ULID, status enums.CommentStatus) {
+	message := "Comment created successfully"
+	if status == enums.CommentPending {
+		message = "Comment created and waiting for moderation"
+	}
+
 	WriteJSON(w, http.StatusCreated, CreatedCommentResponse{
-		Message:   "Comment created successfully",
+		Message:   message,
 		CommentId: commentId,
+		Status:    status,
 		Timestamp: time.Now().UTC(),
 	})
 }
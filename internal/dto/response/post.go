@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
 	"github.com/clemilsonazevedo/blog/pkg"
 )
 
@@ -17,8 +18,29 @@ type PostResponse struct {
 	Likes     int       `json:"likes"`
 	Dislikes  int       `json:"dislikes"`
 	Views     int       `json:"views"`
+	Tags      []string  `json:"tags"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	Language     enums.Language   `json:"language" swaggertype:"string" enums:"pt-br,en"`
+	Status       enums.PostStatus `json:"status" swaggertype:"string" enums:"draft,scheduled,published,archived"`
+	PublishedAt  *time.Time       `json:"published_at,omitempty"`
+	ScheduledFor *time.Time       `json:"scheduled_for,omitempty"`
+	UserReaction enums.Reaction   `json:"user_reaction,omitempty" swaggertype:"string" enums:"like,dislike"`
+}
+
+// PostSearchResponse is a search hit. Snippet is escaped HTML, the only
+// markup are the <mark> tags around the matches.
+type PostSearchResponse struct {
+	ID          pkg.ULID       `json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	AuthorId    pkg.ULID       `json:"author_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Title       string         `json:"title"`
+	Slug        string         `json:"slug"`
+	Language    enums.Language `json:"language" swaggertype:"string" enums:"pt-br,en"`
+	Snippet     string         `json:"snippet" example:"learning <mark>go</mark> generics"`
+	Rank        float64        `json:"rank"`
+	CreatedAt   time.Time      `json:"created_at"`
+	PublishedAt *time.Time     `json:"published_at,omitempty"`
 }
 
 type CreatedPostResponse struct {
@@ -0,0 +1,13 @@
+package response
+
+import (
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+type PostReactionResponse struct {
+	PostID   pkg.ULID       `json:"post_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Reaction enums.Reaction `json:"reaction,omitempty" swaggertype:"string" enums:"like,dislike"`
+	Likes    int            `json:"likes"`
+	Dislikes int            `json:"dislikes"`
+}
@@ -0,0 +1,18 @@
+package response
+
+import (
+	"net/http"
+
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+type TagResponse struct {
+	ID        pkg.ULID `json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	Name      string   `json:"name"`
+	Slug      string   `json:"slug"`
+	PostCount int64    `json:"post_count"`
+}
+
+func ListTags(w http.ResponseWriter, tagsObj any) {
+	OK(w, "success", tagsObj)
+}
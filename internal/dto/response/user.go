@@ -11,6 +11,10 @@ import (
 type UserLogin struct {
 	Token   string    `json:"token"`
 	Expires time.Time `json:"exp"`
+
+	SessionID      pkg.ULID  `json:"session_id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
+	RefreshToken   string    `json:"refresh_token"`
+	RefreshExpires time.Time `json:"refresh_exp"`
 }
 
 type UserLogout struct {
@@ -27,7 +31,10 @@ type UserProfile struct {
 	ID       pkg.ULID   `json:"id" swaggertype:"string" example:"01ARZ3NDEKTSV4RRFFQ69G5FAV"`
 	UserName string     `json:"username"`
 	Email    string     `json:"email"`
-	Role     enums.Role `json:"role" binding:"required,oneof=anonymous reader author" swaggertype:"string" enums:"anonymous,reader,author"`
+	Role     enums.Role `json:"role" binding:"required,oneof=anonymous reader author moderator admin" swaggertype:"string" enums:"anonymous,reader,author,moderator,admin"`
+
+	EmailVerified    bool `json:"email_verified"`
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
 }
 
 type UserDeleted struct {
@@ -41,3 +48,18 @@ func CreatedUser(w http.ResponseWriter, userID string) {
 		Timestamp: time.Now().UTC(),
 	})
 }
+
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool      `json:"two_factor_required"`
+	ChallengeToken    string    `json:"challenge_token"`
+	Expires           time.Time `json:"exp"`
+}
+
+type TwoFactorEnrollment struct {
+	Secret     string `json:"secret" example:"JBSWY3DPEHPK3PXP"`
+	OtpauthURI string `json:"otpauth_uri" example:"otpauth://totp/Blog:user@blog.io?secret=JBSWY3DPEHPK3PXP&issuer=Blog"`
+}
+
+type TwoFactorRecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k3v9x-0qa7m"`
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+// ApiTokenPrefix starts every API token, it tells them apart from JWTs in
+// the Authorization header and makes leaked tokens easy to search for.
+const ApiTokenPrefix = "blog_"
+
+const prefixAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// NewApiToken returns a token like blog_<id>_<secret>, the public prefix
+// (blog_<id>) and the hash of the whole token.
+func NewApiToken() (string, string, string, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", "", err
+	}
+	for i := range id {
+		id[i] = prefixAlphabet[int(id[i])%len(prefixAlphabet)]
+	}
+
+	secret, _, err := NewOpaqueToken()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	prefix := ApiTokenPrefix + string(id)
+	token := prefix + "_" + secret
+	return token, prefix, HashOpaqueToken(token), nil
+}
+
+func IsApiToken(token string) bool {
+	return strings.HasPrefix(token, ApiTokenPrefix)
+}
+
+// SplitApiToken returns the public prefix of an API token.
+func SplitApiToken(token string) (string, bool) {
+	rest, ok := strings.CutPrefix(token, ApiTokenPrefix)
+	if !ok {
+		return "", false
+	}
+
+	id, secret, ok := strings.Cut(rest, "_")
+	if !ok || id == "" || secret == "" {
+		return "", false
+	}
+	return ApiTokenPrefix + id, true
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/pkg"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const twoFactorPurpose = "2fa_challenge"
+
+var ErrInvalidChallenge = errors.New("invalid or expired two factor challenge")
+
+// TwoFactorChallenge is the first step of a login with two factor. ID tells
+// the challenges apart, so the wrong codes of each one can be counted.
+type TwoFactorChallenge struct {
+	ID        string
+	UserID    pkg.ULID
+	Device    string
+	ExpiresAt time.Time
+}
+
+// GenerateChallengeToken is returned by the login when the password is right
+// but the account uses two factor authentication. It only proves the first
+// step was done, it is never accepted as an access token.
+func GenerateChallengeToken(userId pkg.ULID, device string, td time.Duration) (string, time.Time, error) {
+	jti, err := pkg.NewULID()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	exp := now.Add(td)
+	claims := jwt.MapClaims{
+		"jti":     jti.String(),
+		"sub":     userId.String(),
+		"device":  device,
+		"purpose": twoFactorPurpose,
+		"iat":     now.Unix(),
+		"exp":     exp.Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(purposeKey(twoFactorPurpose))
+	return signed, exp, err
+}
+
+// ValidateChallengeToken returns the challenge with the user and the device
+// name sent in the first step of the login.
+func ValidateChallengeToken(tokenStr string) (*TwoFactorChallenge, error) {
+	token, err := jwt.Parse(
+		tokenStr,
+		func(token *jwt.Token) (any, error) {
+			return purposeKey(twoFactorPurpose), nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired())
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidChallenge
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != twoFactorPurpose {
+		return nil, ErrInvalidChallenge
+	}
+
+	jti, _ := claims["jti"].(string)
+	sub, _ := claims["sub"].(string)
+	device, _ := claims["device"].(string)
+	userId, err := pkg.ParseULID(sub)
+	if err != nil || jti == "" {
+		return nil, ErrInvalidChallenge
+	}
+
+	exp, err := claims.GetExpirationTime()
+	if err != nil {
+		return nil, ErrInvalidChallenge
+	}
+
+	return &TwoFactorChallenge{
+		ID:        jti,
+		UserID:    userId,
+		Device:    device,
+		ExpiresAt: exp.Time,
+	}, nil
+}
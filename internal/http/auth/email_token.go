@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/config/secret"
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	VerifyEmailPurpose = "verify_email"
+	ChangeEmailPurpose = "change_email"
+)
+
+var ErrInvalidEmailToken = errors.New("invalid or expired email token")
+
+type EmailClaims struct {
+	UserID pkg.ULID
+	// Email is the address the token was sent to
+	Email string
+	// CurrentEmail is the address of the account when the token was issued,
+	// the token stops working once it changes
+	CurrentEmail string
+}
+
+// GenerateEmailToken signs a token sent by email to prove the user owns the
+// address. Each purpose is signed with its own key, so these tokens are never
+// accepted as session tokens (and vice versa).
+func GenerateEmailToken(purpose string, user entities.User, email string, td time.Duration) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub":     user.ID.String(),
+		"email":   email,
+		"current": user.Email,
+		"purpose": purpose,
+		"iat":     now.Unix(),
+		"exp":     now.Add(td).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(purposeKey(purpose))
+}
+
+func ValidateEmailToken(purpose string, tokenStr string) (*EmailClaims, error) {
+	token, err := jwt.Parse(
+		tokenStr,
+		func(token *jwt.Token) (any, error) {
+			return purposeKey(purpose), nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidEmailToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != purpose {
+		return nil, ErrInvalidEmailToken
+	}
+
+	sub, _ := claims["sub"].(string)
+	email, _ := claims["email"].(string)
+	current, _ := claims["current"].(string)
+	userId, err := pkg.ParseULID(sub)
+	if err != nil || email == "" || current == "" {
+		return nil, ErrInvalidEmailToken
+	}
+
+	return &EmailClaims{
+		UserID:       userId,
+		Email:        email,
+		CurrentEmail: current,
+	}, nil
+}
+
+// purposeKey derives the signing key of the tokens that are not access
+// tokens, one per purpose.
+func purposeKey(purpose string) []byte {
+	return []byte(secret.GetJWTSecret() + ":" + purpose)
+}
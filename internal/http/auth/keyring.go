@@ -0,0 +1,276 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"sync/atomic"
+
+	"github.com/clemilsonazevedo/blog/config/secret"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrUnknownSigningKey = errors.New("unknown signing key")
+
+// SigningKey is a key of the keyring. Keys without a private part only
+// verify tokens, they are the old keys kept during a rotation.
+type SigningKey struct {
+	ID        string
+	Algorithm string
+
+	signKey   any
+	verifyKey any
+}
+
+func (k *SigningKey) CanSign() bool {
+	return k.signKey != nil
+}
+
+func (k *SigningKey) method() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+// NewHMACKey is the HS256 key of a shared secret, it is never published in
+// the JWKS.
+func NewHMACKey(id string, secret string) *SigningKey {
+	return &SigningKey{
+		ID:        id,
+		Algorithm: jwt.SigningMethodHS256.Alg(),
+		signKey:   []byte(secret),
+		verifyKey: []byte(secret),
+	}
+}
+
+// ParsePEMKey reads an Ed25519 (EdDSA) or RSA (RS256) key. A private key
+// signs and verifies, a public key ("PUBLIC KEY" block) only verifies.
+//
+//	openssl genpkey -algorithm ed25519 -out jwt-2026-01.pem
+//	openssl genpkey -algorithm rsa -pkeyopt rsa_keygen_bits:3072 -out jwt-2026-01.pem
+func ParsePEMKey(id string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %s: no PEM data", id)
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %s: unsupported PEM block %q", id, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", id, err)
+	}
+
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return &SigningKey{ID: id, Algorithm: jwt.SigningMethodEdDSA.Alg(), signKey: k, verifyKey: k.Public()}, nil
+	case ed25519.PublicKey:
+		return &SigningKey{ID: id, Algorithm: jwt.SigningMethodEdDSA.Alg(), verifyKey: k}, nil
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < 2048 {
+			return nil, fmt.Errorf("key %s: RSA keys need at least 2048 bits", id)
+		}
+		return &SigningKey{ID: id, Algorithm: jwt.SigningMethodRS256.Alg(), signKey: k, verifyKey: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		return &SigningKey{ID: id, Algorithm: jwt.SigningMethodRS256.Alg(), verifyKey: k}, nil
+	}
+	return nil, fmt.Errorf("key %s: only Ed25519 and RSA keys are supported", id)
+}
+
+// Keyring signs the access tokens with its active key and verifies them with
+// any of its keys, picked by the kid header. To rotate, add the new key, make
+// it active and keep the old one until the tokens it signed expired.
+type Keyring struct {
+	active *SigningKey
+	keys   map[string]*SigningKey
+	order  []string
+}
+
+func NewKeyring(active string, keys ...*SigningKey) (*Keyring, error) {
+	keyring := &Keyring{keys: map[string]*SigningKey{}}
+	for _, key := range keys {
+		if key.ID == "" {
+			return nil, errors.New("keyring: a key has no id")
+		}
+		if _, ok := keyring.keys[key.ID]; ok {
+			return nil, fmt.Errorf("keyring: duplicated key id %s", key.ID)
+		}
+		keyring.keys[key.ID] = key
+		keyring.order = append(keyring.order, key.ID)
+	}
+
+	keyring.active = keyring.keys[active]
+	if keyring.active == nil {
+		return nil, fmt.Errorf("keyring: active key %q not found", active)
+	}
+	if !keyring.active.CanSign() {
+		return nil, fmt.Errorf("keyring: active key %q has no private key", active)
+	}
+
+	return keyring, nil
+}
+
+// KeyFile is a PEM key of the keyring and its kid.
+type KeyFile struct {
+	ID   string
+	Path string
+}
+
+// LoadKeyring reads the PEM keys, active defaults to the first one. Without
+// files the keyring only has the HS256 key of the JWT secret.
+func LoadKeyring(files []KeyFile, active string) (*Keyring, error) {
+	if len(files) == 0 {
+		return defaultKeyring()
+	}
+
+	keys := make([]*SigningKey, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file.Path)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", file.ID, err)
+		}
+
+		key, err := ParsePEMKey(file.ID, data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	if active == "" {
+		active = files[0].ID
+	}
+	return NewKeyring(active, keys...)
+}
+
+// defaultKeyring uses the JWT secret, its kid is derived from the secret so
+// a new secret is a new key.
+func defaultKeyring() (*Keyring, error) {
+	sum := sha256.Sum256([]byte(secret.GetJWTSecret()))
+	id := "hs256-" + hex.EncodeToString(sum[:4])
+	return NewKeyring(id, NewHMACKey(id, secret.GetJWTSecret()))
+}
+
+// Sign issues a token with the active key and its kid.
+func (k *Keyring) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(k.active.method(), claims)
+	token.Header["kid"] = k.active.ID
+	return token.SignedString(k.active.signKey)
+}
+
+// Parse verifies a token with the key of its kid. The algorithm must be the
+// one of the key, a token cannot pick another one.
+func (k *Keyring) Parse(tokenStr string) (*jwt.Token, jwt.MapClaims, error) {
+	algorithms := make([]string, 0, len(k.keys))
+	for _, key := range k.keys {
+		if !slices.Contains(algorithms, key.Algorithm) {
+			algorithms = append(algorithms, key.Algorithm)
+		}
+	}
+
+	token, err := jwt.Parse(
+		tokenStr,
+		func(token *jwt.Token) (any, error) {
+			kid, _ := token.Header["kid"].(string)
+			key, ok := k.keys[kid]
+			if !ok {
+				return nil, ErrUnknownSigningKey
+			}
+			if token.Method.Alg() != key.Algorithm {
+				return nil, jwt.ErrTokenSignatureInvalid
+			}
+			return key.verifyKey, nil
+		},
+		jwt.WithValidMethods(algorithms))
+	if err != nil || !token.Valid {
+		return nil, nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, nil, jwt.ErrInvalidKeyType
+	}
+
+	return token, claims, nil
+}
+
+// JWK is a public key in the JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS lists the public keys, HMAC keys are secret and left out.
+func (k *Keyring) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, id := range k.order {
+		switch key := k.keys[id].verifyKey.(type) {
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kid: id,
+				Kty: "OKP",
+				Alg: jwt.SigningMethodEdDSA.Alg(),
+				Use: "sig",
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(key),
+			})
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JWK{
+				Kid: id,
+				Kty: "RSA",
+				Alg: jwt.SigningMethodRS256.Alg(),
+				Use: "sig",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			})
+		}
+	}
+	return set
+}
+
+var currentKeyring atomic.Pointer[Keyring]
+
+// SetKeyring replaces the keyring of the access tokens, called on startup.
+func SetKeyring(keyring *Keyring) {
+	currentKeyring.Store(keyring)
+}
+
+// CurrentKeyring returns the configured keyring, or the keyring of the JWT
+// secret when none was set.
+func CurrentKeyring() *Keyring {
+	if keyring := currentKeyring.Load(); keyring != nil {
+		return keyring
+	}
+
+	keyring, _ := defaultKeyring()
+	currentKeyring.CompareAndSwap(nil, keyring)
+	return currentKeyring.Load()
+}
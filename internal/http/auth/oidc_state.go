@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const oidcStatePurpose = "oidc_state"
+
+var ErrInvalidOIDCState = errors.New("invalid or expired oidc login state")
+
+// OIDCState is kept in a cookie between the redirect to the provider and the
+// callback: the state sent back by the provider must match, the nonce must be
+// in the ID token and the verifier completes the PKCE exchange.
+type OIDCState struct {
+	Provider string
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+func GenerateOIDCStateToken(state OIDCState, td time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	exp := now.Add(td)
+	claims := jwt.MapClaims{
+		"provider": state.Provider,
+		"state":    state.State,
+		"nonce":    state.Nonce,
+		"verifier": state.Verifier,
+		"purpose":  oidcStatePurpose,
+		"iat":      now.Unix(),
+		"exp":      exp.Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	signed, err := token.SignedString(purposeKey(oidcStatePurpose))
+	return signed, exp, err
+}
+
+func ValidateOIDCStateToken(tokenStr string) (*OIDCState, error) {
+	token, err := jwt.Parse(
+		tokenStr,
+		func(token *jwt.Token) (any, error) {
+			return purposeKey(oidcStatePurpose), nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil || !token.Valid {
+		return nil, ErrInvalidOIDCState
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["purpose"] != oidcStatePurpose {
+		return nil, ErrInvalidOIDCState
+	}
+
+	var state OIDCState
+	state.Provider, _ = claims["provider"].(string)
+	state.State, _ = claims["state"].(string)
+	state.Nonce, _ = claims["nonce"].(string)
+	state.Verifier, _ = claims["verifier"].(string)
+	if state.State == "" || state.Verifier == "" {
+		return nil, ErrInvalidOIDCState
+	}
+
+	return &state, nil
+}
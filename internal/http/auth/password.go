@@ -1,15 +1,218 @@
 package auth
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
 
-func HashPassword(plain string) (string, error) {
-	h, err := bcrypt.GenerateFromPassword([]byte(plain), bcrypt.DefaultCost)
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	HashBcrypt   = "bcrypt"
+	HashArgon2id = "argon2id"
+)
+
+var ErrUnknownPasswordHash = errors.New("unknown password hash")
+
+// PasswordHasher hashes the passwords of the users. Verify accepts hashes of
+// older parameters, NeedsRehash tells when a hash is weaker than the hasher
+// would produce today.
+type PasswordHasher interface {
+	Hash(plain string) (string, error)
+	Verify(hash string, plain string) bool
+	NeedsRehash(hash string) bool
+}
+
+// BcryptHasher hashes with bcrypt, it only reads the first 72 bytes of a
+// password.
+type BcryptHasher struct {
+	Cost int
+}
+
+func (h BcryptHasher) Hash(plain string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(plain), h.Cost)
 	if err != nil {
 		return "", err
 	}
-	return string(h), nil
+	return string(hash), nil
 }
 
-func CheckPassword(hash string, plain string) bool {
+func (h BcryptHasher) Verify(hash string, plain string) bool {
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(plain)) == nil
 }
+
+func (h BcryptHasher) NeedsRehash(hash string) bool {
+	if !isBcryptHash(hash) {
+		return true
+	}
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost < h.Cost
+}
+
+// Argon2idHasher hashes with argon2id, Memory is in KiB. The hashes use the
+// PHC string format: $argon2id$v=19$m=65536,t=3,p=2$salt$key
+type Argon2idHasher struct {
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+	KeyLen  uint32
+	SaltLen uint32
+}
+
+type argon2Params struct {
+	time, memory uint32
+	threads      uint8
+	salt, key    []byte
+}
+
+func (h Argon2idHasher) Hash(plain string) (string, error) {
+	salt := make([]byte, h.SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(plain), salt, h.Time, h.Memory, h.Threads, h.KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, h.Memory, h.Time, h.Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h Argon2idHasher) Verify(hash string, plain string) bool {
+	params, err := parseArgon2id(hash)
+	if err != nil {
+		return false
+	}
+
+	key := argon2.IDKey([]byte(plain), params.salt, params.time, params.memory, params.threads, uint32(len(params.key)))
+	return subtle.ConstantTimeCompare(key, params.key) == 1
+}
+
+func (h Argon2idHasher) NeedsRehash(hash string) bool {
+	params, err := parseArgon2id(hash)
+	if err != nil {
+		return true
+	}
+
+	return params.time < h.Time ||
+		params.memory < h.Memory ||
+		uint32(len(params.key)) < h.KeyLen ||
+		uint32(len(params.salt)) < h.SaltLen
+}
+
+func parseArgon2id(hash string) (*argon2Params, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != HashArgon2id {
+		return nil, ErrUnknownPasswordHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, ErrUnknownPasswordHash
+	}
+
+	var params argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return nil, ErrUnknownPasswordHash
+	}
+
+	var err error
+	if params.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, ErrUnknownPasswordHash
+	}
+	if params.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil || len(params.key) == 0 {
+		return nil, ErrUnknownPasswordHash
+	}
+
+	return &params, nil
+}
+
+func isBcryptHash(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") ||
+		strings.HasPrefix(hash, "$2b$") ||
+		strings.HasPrefix(hash, "$2y$")
+}
+
+// NewPasswordHasher returns the hasher of an algorithm. Zero parameters take
+// the defaults: bcrypt cost 12, argon2id t=3 m=64MiB p=2.
+func NewPasswordHasher(algorithm string, cost int, memory int, threads int) (PasswordHasher, error) {
+	switch algorithm {
+	case HashBcrypt, "":
+		if cost == 0 {
+			cost = 12
+		}
+		if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost %d out of range %d-%d", cost, bcrypt.MinCost, bcrypt.MaxCost)
+		}
+		return BcryptHasher{Cost: cost}, nil
+	case HashArgon2id:
+		if cost == 0 {
+			cost = 3
+		}
+		if memory == 0 {
+			memory = 64 * 1024
+		}
+		if threads == 0 {
+			threads = 2
+		}
+		if cost < 1 || memory < 8*threads || threads < 1 || threads > 255 {
+			return nil, fmt.Errorf("invalid argon2id parameters t=%d m=%d p=%d", cost, memory, threads)
+		}
+		return Argon2idHasher{
+			Time:    uint32(cost),
+			Memory:  uint32(memory),
+			Threads: uint8(threads),
+			KeyLen:  32,
+			SaltLen: 16,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unknown password hash algorithm %q", algorithm)
+}
+
+var currentPasswordHasher atomic.Pointer[PasswordHasher]
+
+// SetPasswordHasher replaces the hasher of the new passwords, called on
+// startup.
+func SetPasswordHasher(hasher PasswordHasher) {
+	currentPasswordHasher.Store(&hasher)
+}
+
+// CurrentPasswordHasher returns the configured hasher, bcrypt with the
+// default cost when none was set.
+func CurrentPasswordHasher() PasswordHasher {
+	if hasher := currentPasswordHasher.Load(); hasher != nil {
+		return *hasher
+	}
+	return BcryptHasher{Cost: 12}
+}
+
+// HashPassword hashes with the configured algorithm.
+func HashPassword(plain string) (string, error) {
+	return CurrentPasswordHasher().Hash(plain)
+}
+
+// CheckPassword verifies a hash of any supported algorithm, so the stored
+// hashes keep working after the algorithm changes.
+func CheckPassword(hash string, plain string) bool {
+	switch {
+	case isBcryptHash(hash):
+		return BcryptHasher{}.Verify(hash, plain)
+	case strings.HasPrefix(hash, "$"+HashArgon2id+"$"):
+		return Argon2idHasher{}.Verify(hash, plain)
+	}
+	return false
+}
+
+// PasswordNeedsRehash reports whether a stored hash is weaker than the
+// configured algorithm and cost.
+func PasswordNeedsRehash(hash string) bool {
+	return CurrentPasswordHasher().NeedsRehash(hash)
+}
@@ -3,16 +3,19 @@ package auth
 import (
 	"time"
 
-	"github.com/clemilsonazevedo/blog/config/secret"
 	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/pkg"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-func GenerateJWT(user entities.User, td time.Duration) (string, int64, error) {
+// GenerateJWT issues an access token for the session (sid) of the user,
+// signed by the active key of the keyring.
+func GenerateJWT(user entities.User, sessionId pkg.ULID, td time.Duration) (string, int64, error) {
 	now := time.Now()
 	exp := now.Add(td).Unix()
 	claims := jwt.MapClaims{
 		"id":        user.ID,
+		"sid":       sessionId.String(),
 		"UserName":  user.UserName,
 		"Email":     user.Email,
 		"Role":      user.Role,
@@ -21,27 +24,47 @@ func GenerateJWT(user entities.User, td time.Duration) (string, int64, error) {
 		"exp":       exp,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString([]byte(secret.GetJWTSecret()))
+	signed, err := CurrentKeyring().Sign(claims)
 
 	return signed, exp, err
 }
 
+// ValidateJWT verifies an access token with the keyring, see Keyring.Parse.
 func ValidateJWT(tokenStr string) (*jwt.Token, jwt.MapClaims, error) {
-	token, err := jwt.Parse(
-		tokenStr,
-		func(token *jwt.Token) (any, error) {
-			return []byte(secret.GetJWTSecret()), nil
-		},
-		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
-	if err != nil || !token.Valid {
-		return nil, nil, err
+	return CurrentKeyring().Parse(tokenStr)
+}
+
+// IssuedBefore reports whether the token was issued before t, used to
+// reject the sessions of a user that were revoked.
+func IssuedBefore(claims jwt.MapClaims, t *time.Time) bool {
+	if t == nil {
+		return false
+	}
+
+	iat, err := claims.GetIssuedAt()
+	if err != nil || iat == nil {
+		return true
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok {
-		return nil, nil, jwt.ErrInvalidKeyType
+	return iat.Unix() < t.Unix()
+}
+
+// UserID returns the user the access token was issued to.
+func UserID(claims jwt.MapClaims) (pkg.ULID, bool) {
+	id, _ := claims["id"].(string)
+	userId, err := pkg.ParseULID(id)
+	if err != nil {
+		return pkg.ULID{}, false
 	}
+	return userId, true
+}
 
-	return token, claims, nil
+// SessionID returns the session the access token belongs to.
+func SessionID(claims jwt.MapClaims) (pkg.ULID, bool) {
+	sid, _ := claims["sid"].(string)
+	sessionId, err := pkg.ParseULID(sid)
+	if err != nil {
+		return pkg.ULID{}, false
+	}
+	return sessionId, true
 }
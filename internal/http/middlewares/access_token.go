@@ -0,0 +1,33 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+var (
+	errMissingToken        = errors.New("token is missing")
+	errMalformedAuthHeader = errors.New("authorization header must be \"Bearer <token>\"")
+)
+
+// accessToken reads the token of the request. When the Authorization header
+// is present it is the only source: a malformed header is an error even if
+// the request also has the token cookie. Only requests without the header
+// fall back to the cookie, so browsers keep working unchanged.
+func accessToken(r *http.Request) (string, error) {
+	if header := r.Header.Get("Authorization"); header != "" {
+		scheme, token, found := strings.Cut(header, " ")
+		token = strings.TrimSpace(token)
+		if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return "", errMalformedAuthHeader
+		}
+		return token, nil
+	}
+
+	cookie, err := r.Cookie("token")
+	if err != nil || cookie.Value == "" {
+		return "", errMissingToken
+	}
+	return cookie.Value, nil
+}
@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/clemilsonazevedo/blog/internal/http/auth"
+	"github.com/clemilsonazevedo/blog/internal/service"
+)
+
+// OptionalAuth loads the logged user into the context like RequireAuth (same
+// token sources), but lets anonymous requests (or requests with a bad token)
+// through untouched.
+func OptionalAuth(us *service.UserService, ss *service.SessionService, ts *service.ApiTokenService) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tokenStr, err := accessToken(r)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if auth.IsApiToken(tokenStr) {
+				apiToken, user, err := ts.Authenticate(tokenStr)
+				if err != nil || us.CheckAccountStatus(user) != nil {
+					next.ServeHTTP(w, r)
+					return
+				}
+
+				ctx := context.WithValue(r.Context(), "user", user)
+				ctx = context.WithValue(ctx, "apiToken", apiToken)
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			_, claim, err := auth.ValidateJWT(tokenStr)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userId, ok := auth.UserID(claim)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			user, err := us.GetUserByID(userId)
+			if err != nil || auth.IssuedBefore(claim, user.SessionsRevokedAt) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			sessionId, ok := auth.SessionID(claim)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if active, err := ss.IsActive(user.ID, sessionId); err != nil || !active || us.CheckAccountStatus(user) != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), "user", user)
+			ctx = context.WithValue(ctx, "sessionId", sessionId)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
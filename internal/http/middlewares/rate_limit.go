@@ -0,0 +1,99 @@
+package middlewares
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+	"github.com/clemilsonazevedo/blog/internal/service"
+)
+
+// RateLimits are the policies of each route group, all the buckets are kept
+// in Store.
+type RateLimits struct {
+	Store service.RateLimitStore
+
+	// Auth covers login, registration and the password and email links,
+	// per IP.
+	Auth service.RateLimitPolicy
+	// Read covers the public reads, per IP.
+	Read service.RateLimitPolicy
+	// Account covers every authenticated route, per API token or user.
+	Account service.RateLimitPolicy
+	// Write covers the creation and edition of posts and comments, per API
+	// token or user, on top of Account.
+	Write service.RateLimitPolicy
+	// AI covers the post suggestions, each one is a paid OpenAI call.
+	AI service.RateLimitPolicy
+}
+
+// RateLimitKey identifies the client of a request, it gets one bucket per
+// policy.
+type RateLimitKey func(r *http.Request) string
+
+// RateLimitByIP keys by the address of the client.
+func RateLimitByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return "ip:" + r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// RateLimitByUser keys by the logged user, by IP for anonymous requests.
+func RateLimitByUser(r *http.Request) string {
+	if user, ok := r.Context().Value("user").(*entities.User); ok {
+		return "user:" + user.ID.String()
+	}
+	return RateLimitByIP(r)
+}
+
+// RateLimitByApiToken keys by the API token of the request, so each token
+// of a user has its own bucket, by user otherwise.
+func RateLimitByApiToken(r *http.Request) string {
+	if token, ok := r.Context().Value("apiToken").(*entities.ApiToken); ok {
+		return "token:" + token.ID.String()
+	}
+	return RateLimitByUser(r)
+}
+
+// RateLimit takes a token of the bucket of the client for each request and
+// refuses the request with 429 when the bucket is empty. The answers carry
+// the RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and
+// RateLimit-Policy headers. The requests go through when the store fails,
+// an outage of the store must not take the API down.
+func RateLimit(store service.RateLimitStore, policy service.RateLimitPolicy, key RateLimitKey) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !policy.Enabled() {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			result, err := store.Take(policy.Name+":"+key(r), policy, time.Now())
+			if err != nil {
+				log.Printf("rate limit %s: %v", policy.Name, err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			header := w.Header()
+			header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+			header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			header.Set("RateLimit-Reset", strconv.Itoa(int(math.Ceil(result.Reset.Seconds()))))
+			header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Limit, int(policy.Period.Seconds())))
+
+			if !result.Allowed {
+				exceptions.TooManyRequests(w, service.ErrRateLimited, "Too many requests, try again later", result.RetryAfter)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -10,21 +10,44 @@ import (
 	"github.com/clemilsonazevedo/blog/internal/service"
 )
 
-func RequireAuth(us *service.UserService) func(http.Handler) http.Handler {
+// RequireAuth loads the user of the access token into the context, with the
+// ID of its session under "sessionId". Tokens of revoked sessions are refused,
+// and so are suspended or banned users.
+// The token comes from the "Authorization: Bearer" header or, when there is
+// no such header, from the token cookie.
+//
+// API tokens (see auth.ApiTokenPrefix) are accepted in the header too, the
+// token is then stored under "apiToken" for RequireScope and RequireSession.
+func RequireAuth(us *service.UserService, ss *service.SessionService, ts *service.ApiTokenService) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
 
-			tokenStr := ""
-			cookie, err := r.Cookie("token")
+			tokenStr, err := accessToken(r)
 			if err != nil {
-				exceptions.Unauthorized(w, "Token is missing!")
+				if errors.Is(err, errMissingToken) {
+					exceptions.Unauthorized(w, "Token is missing!")
+					return
+				}
+				exceptions.BadRequest(w, err, "The Authorization header is invalid.", nil)
 				return
 			}
 
-			tokenStr = cookie.Value
-			if tokenStr == "" {
-				exceptions.BadRequest(w, errors.New("Request Error"), "The token is empty.", nil)
+			if auth.IsApiToken(tokenStr) {
+				apiToken, user, err := ts.Authenticate(tokenStr)
+				if err != nil {
+					exceptions.Unauthorized(w, "The API token is invalid, expired or revoked.")
+					return
+				}
+
+				if err := us.CheckAccountStatus(user); err != nil {
+					exceptions.Forbidden(w, err, "This account is suspended or banned.")
+					return
+				}
+
+				ctx = context.WithValue(ctx, "user", user)
+				ctx = context.WithValue(ctx, "apiToken", apiToken)
+				next.ServeHTTP(w, r.WithContext(ctx))
 				return
 			}
 
@@ -34,18 +57,37 @@ func RequireAuth(us *service.UserService) func(http.Handler) http.Handler {
 				return
 			}
 
-			email, _ := claim["Email"].(string)
-			if email == "" {
+			userId, ok := auth.UserID(claim)
+			if !ok {
 				exceptions.Unauthorized(w, "Unauthorized account.")
+				return
 			}
 
-			user, err := us.GetUserByEmail(email)
+			user, err := us.GetUserByID(userId)
 			if err != nil {
 				exceptions.BadRequest(w, errors.New("Request Error"), "User not found.", nil)
 				return
 			}
 
+			sessionId, ok := auth.SessionID(claim)
+			if !ok || auth.IssuedBefore(claim, user.SessionsRevokedAt) {
+				exceptions.Unauthorized(w, "Session revoked, login again.")
+				return
+			}
+
+			active, err := ss.IsActive(user.ID, sessionId)
+			if err != nil || !active {
+				exceptions.Unauthorized(w, "Session revoked, login again.")
+				return
+			}
+
+			if err := us.CheckAccountStatus(user); err != nil {
+				exceptions.Forbidden(w, err, "This account is suspended or banned.")
+				return
+			}
+
 			ctx = context.WithValue(ctx, "user", user)
+			ctx = context.WithValue(ctx, "sessionId", sessionId)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -0,0 +1,38 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/clemilsonazevedo/blog/internal/authz"
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+	"github.com/clemilsonazevedo/blog/internal/service"
+)
+
+// RequirePermission lets through the users whose role grants the action, at
+// least on their own resources: the controller still checks the resource
+// with authz.Can. Roles that must use two factor authentication (see
+// UserService.RequiresTwoFactor) are refused until it is enabled.
+func RequirePermission(us *service.UserService, action authz.Action) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := r.Context().Value("user").(*entities.User)
+			if !ok {
+				exceptions.Unauthorized(w, "You need login to this route")
+				return
+			}
+
+			if !authz.Grants(user, action) {
+				exceptions.Forbidden(w, authz.ErrForbidden, "Your role cannot use this route")
+				return
+			}
+
+			if us.RequiresTwoFactor(user) {
+				exceptions.Forbidden(w, service.ErrTwoFactorRequired, "Enable two factor authentication to use this route")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
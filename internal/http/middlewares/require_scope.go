@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+)
+
+// RequireScope must run after RequireAuth. Requests authenticated with an API
+// token need the scope, logged users (sessions) are not limited by scopes.
+func RequireScope(scope enums.ApiScope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiToken, ok := r.Context().Value("apiToken").(*entities.ApiToken)
+			if ok && !apiToken.HasScope(scope) {
+				exceptions.Forbidden(w, errors.New("missing scope"), fmt.Sprintf("The API token needs the %s scope", scope))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireSession must run after RequireAuth, it refuses API tokens. Used on
+// the account routes so a token cannot change the password, create other
+// tokens...
+func RequireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Value("apiToken").(*entities.ApiToken); ok {
+			exceptions.Forbidden(w, errors.New("api token not allowed"), "This route cannot be used with an API token")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,28 @@
+package middlewares
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/exceptions"
+)
+
+// RequireVerifiedEmail must run after RequireAuth, it blocks users that did
+// not confirm their email yet.
+func RequireVerifiedEmail(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := r.Context().Value("user").(*entities.User)
+		if !ok {
+			exceptions.Unauthorized(w, "You need login to this route")
+			return
+		}
+
+		if !user.IsEmailVerified() {
+			exceptions.Forbidden(w, errors.New("email not verified"), "Confirm your email to use this route")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -1,7 +1,11 @@
 package private
 
 import (
+	"net/http"
+
+	"github.com/clemilsonazevedo/blog/internal/authz"
 	"github.com/clemilsonazevedo/blog/internal/controller"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
 	"github.com/clemilsonazevedo/blog/internal/http/middlewares"
 	"github.com/clemilsonazevedo/blog/internal/service"
 	"github.com/go-chi/chi/v5"
@@ -10,37 +14,108 @@ import (
 type UserController = controller.UserController
 type PostController = controller.PostController
 type CommentController = controller.CommentController
+type ApiTokenController = controller.ApiTokenController
+type AdminController = controller.AdminController
+type AuditController = controller.AuditController
 
 type UserService = service.UserService
+type SessionService = service.SessionService
+type ApiTokenService = service.ApiTokenService
 
 func BindPrivateRoutes(
 	pc *PostController,
 	uc *UserController,
 	cc *CommentController,
+	atc *ApiTokenController,
+	ac *AdminController,
+	auc *AuditController,
 	us *UserService,
+	ss *SessionService,
+	ts *ApiTokenService,
+	limits middlewares.RateLimits,
 	c chi.Router,
 ) {
+	postsRead := middlewares.RequireScope(enums.ScopePostsRead)
+	postsWrite := middlewares.RequireScope(enums.ScopePostsWrite)
+	commentsRead := middlewares.RequireScope(enums.ScopeCommentsRead)
+	commentsWrite := middlewares.RequireScope(enums.ScopeCommentsWrite)
+	can := func(action authz.Action) func(http.Handler) http.Handler {
+		return middlewares.RequirePermission(us, action)
+	}
+	writeLimit := middlewares.RateLimit(limits.Store, limits.Write, middlewares.RateLimitByApiToken)
+	aiLimit := middlewares.RateLimit(limits.Store, limits.AI, middlewares.RateLimitByUser)
+
 	c.Group(func(r chi.Router) {
-		r.Use(middlewares.RequireAuth(us))
-		// Auth
-		r.Post("/logout", uc.Logout)
+		r.Use(middlewares.RequireAuth(us, ss, ts))
+		r.Use(middlewares.RateLimit(limits.Store, limits.Account, middlewares.RateLimitByApiToken))
 
-		// Users
-		r.Get("/profiles", uc.Profile)
-		r.Put("/profiles", uc.UpdateUser)
-		r.Delete("/profiles", uc.DeleteUser)
+		// Account, API tokens cannot be used here
+		r.Group(func(s chi.Router) {
+			s.Use(middlewares.RequireSession)
 
-		// Comments
-		r.Post("/comments", cc.CreateComment)
-		r.Delete("/comments", cc.DeleteComment)
-
-		// Author Role
-		r.Group(func(a chi.Router) {
-			a.Use(middlewares.RequireAuthorRole(us))
-			a.Post("/posts", pc.CreatePost)
-			a.Post("/posts/suggest", pc.CreatePostWithAi)
-			a.Put("/posts", pc.UpdatePost)
-			a.Delete("/posts", pc.DeletePost)
+			// Auth
+			s.Post("/logout", uc.Logout)
+			s.Post("/verify-email/resend", uc.ResendVerificationEmail)
+			s.Get("/sessions", uc.GetSessions)
+			s.Delete("/sessions/{id}", uc.RevokeSession)
+
+			// Users
+			s.Get("/profiles", uc.Profile)
+			s.Put("/profiles", uc.UpdateUser)
+			s.Delete("/profiles", uc.DeleteUser)
+			s.Put("/profiles/password", uc.ChangePassword)
+			s.Put("/profiles/email", uc.ChangeEmail)
+			s.Post("/profiles/2fa/enroll", uc.EnrollTwoFactor)
+			s.Post("/profiles/2fa/confirm", uc.ConfirmTwoFactor)
+			s.Delete("/profiles/2fa", uc.DisableTwoFactor)
+
+			// API tokens
+			s.Post("/tokens", atc.CreateApiToken)
+			s.Get("/tokens", atc.GetApiTokens)
+			s.Delete("/tokens/{id}", atc.RevokeApiToken)
+
+			// Reactions
+			s.Put("/posts/{id}/reaction", pc.ReactToPost)
+			s.Delete("/posts/{id}/reaction", pc.RemovePostReaction)
+
+			// Admin
+			s.Route("/admin", func(a chi.Router) {
+				a.Use(can(authz.UserManage))
+				a.Get("/users", ac.GetUsers)
+				a.Put("/users/{id}/role", ac.ChangeUserRole)
+				a.Post("/users/{id}/suspend", ac.SuspendUser)
+				a.Post("/users/{id}/ban", ac.BanUser)
+				a.Post("/users/{id}/reactivate", ac.ReactivateUser)
+				a.Post("/users/{id}/logout", ac.ForceLogout)
+			})
+
+			// Audit log
+			s.With(can(authz.AuditRead)).Get("/audit", auc.GetAuditEvents)
 		})
+
+		// Comments
+		r.With(commentsWrite, middlewares.RequireVerifiedEmail, can(authz.CommentCreate), writeLimit).Post("/comments", cc.CreateComment)
+		r.With(commentsWrite, can(authz.CommentEdit), writeLimit).Put("/comments/{id}", cc.UpdateComment)
+		r.With(commentsWrite, can(authz.CommentDelete), writeLimit).Delete("/comments", cc.DeleteComment)
+		r.With(commentsRead, can(authz.CommentReadEdits)).Get("/comments/{id}/edits", cc.GetCommentEdits)
+
+		// Comment moderation
+		r.With(commentsRead, can(authz.CommentModerate)).Get("/comments/pending", cc.GetPendingComments)
+		r.With(commentsWrite, can(authz.CommentModerate)).Post("/comments/{id}/approve", cc.ApproveComment)
+		r.With(commentsWrite, can(authz.CommentModerate)).Post("/comments/{id}/reject", cc.RejectComment)
+		r.With(postsWrite, can(authz.CommentModerate)).Put("/posts/{id}/comment-approval", cc.SetPostCommentApproval)
+
+		// Posts
+		r.With(postsWrite, can(authz.PostCreate), writeLimit).Post("/posts", pc.CreatePost)
+		r.With(postsWrite, can(authz.PostCreate), aiLimit).Post("/posts/suggest", pc.CreatePostWithAi)
+		r.With(postsRead, can(authz.PostCreate)).Get("/drafts", pc.GetDrafts)
+		r.With(postsWrite, can(authz.PostEdit), writeLimit).Put("/posts", pc.UpdatePost)
+		r.With(postsWrite, can(authz.PostDelete), writeLimit).Delete("/posts", pc.DeletePost)
+		r.With(postsWrite, can(authz.PostPublish)).Post("/posts/publish", pc.PublishPost)
+		r.With(postsWrite, can(authz.PostPublish)).Post("/posts/unpublish", pc.UnpublishPost)
+		r.With(postsWrite, can(authz.PostPublish)).Post("/posts/archive", pc.ArchivePost)
+		r.With(postsRead, can(authz.PostEdit)).Get("/posts/{id}/revisions", pc.GetPostRevisions)
+		r.With(postsRead, can(authz.PostEdit)).Get("/posts/{id}/revisions/{rev}/diff", pc.DiffPostRevision)
+		r.With(postsWrite, can(authz.PostEdit), writeLimit).Post("/posts/{id}/revisions/{rev}/restore", pc.RestorePostRevision)
 	})
 }
@@ -2,25 +2,56 @@ package public
 
 import (
 	"github.com/clemilsonazevedo/blog/internal/controller"
+	"github.com/clemilsonazevedo/blog/internal/http/middlewares"
+	"github.com/clemilsonazevedo/blog/internal/service"
 	"github.com/go-chi/chi/v5"
 )
 
 type UserController = controller.UserController
 type PostController = controller.PostController
 type CommentController = controller.CommentController
+type TagController = controller.TagController
+type IdentityController = controller.IdentityController
 
-func BindPublicRoutes(uc *UserController, pc *PostController, cc *CommentController,
-	c chi.Router) {
+type UserService = service.UserService
+type SessionService = service.SessionService
+type ApiTokenService = service.ApiTokenService
+
+func BindPublicRoutes(uc *UserController, pc *PostController, cc *CommentController, tc *TagController, ic *IdentityController,
+	us *UserService, ss *SessionService, ts *ApiTokenService, limits middlewares.RateLimits, c chi.Router) {
 	c.Group(func(r chi.Router) {
+		r.Use(middlewares.RateLimit(limits.Store, limits.Auth, middlewares.RateLimitByIP))
+
 		// Auth
 		r.Post("/register", uc.CreateUser)
 		r.Post("/login", uc.LoginUser)
+		r.Post("/login/2fa", uc.LoginTwoFactor)
+		r.Post("/refresh", uc.RefreshSession)
+		r.Post("/verify-email", uc.VerifyEmail)
+		r.Post("/password/forgot", uc.ForgotPassword)
+		r.Post("/password/reset", uc.ResetPassword)
+		r.Post("/profiles/email/confirm", uc.ConfirmEmailChange)
+
+		// External login providers
+		r.Get("/auth/{provider}", ic.LoginWithProvider)
+		r.Get("/auth/{provider}/callback", ic.ProviderCallback)
+	})
+
+	c.Group(func(r chi.Router) {
+		r.Use(middlewares.RateLimit(limits.Store, limits.Read, middlewares.RateLimitByIP))
+
+		r.Get("/auth/providers", ic.GetProviders)
 
 		// Posts
 		r.Get("/posts", pc.GetPaginatedPosts)
-		r.Get("/post", pc.GetPostById)
+		r.With(middlewares.OptionalAuth(us, ss, ts)).Get("/post", pc.GetPostById)
+		r.Get("/posts/{slug}", pc.GetPostBySlug)
+		r.Get("/search", pc.SearchPosts)
 
 		// Comments
 		r.Get("/comments", cc.GetCommentsByPostID)
+
+		// Tags
+		r.Get("/tags", tc.GetAllTags)
 	})
 }
@@ -0,0 +1,37 @@
+package mailer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// LogMailer does not deliver anything, it writes every message to w. Useful
+// in development to grab the links sent by email.
+type LogMailer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewLogMailer(w io.Writer) *LogMailer {
+	return &LogMailer{w: w}
+}
+
+func NewFileMailer(path string) (*LogMailer, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open mail log file: %w", err)
+	}
+	return NewLogMailer(file), nil
+}
+
+func (m *LogMailer) Send(msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, err := fmt.Fprintf(m.w, "---- mail %s ----\nTo: %s\nSubject: %s\n\n%s\n\n",
+		time.Now().UTC().Format(time.RFC3339), msg.To, msg.Subject, msg.Body)
+	return err
+}
@@ -0,0 +1,53 @@
+// Package mailer sends the transactional emails of the blog (account
+// confirmation, password reset...) through a configurable transport.
+package mailer
+
+import (
+	"fmt"
+	"os"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(msg Message) error
+}
+
+const (
+	TransportSMTP   = "smtp"
+	TransportLog    = "log"
+	TransportMemory = "memory"
+)
+
+type Config struct {
+	Transport string
+	From      string
+
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+
+	// LogFile is where the log transport writes, empty means stdout.
+	LogFile string
+}
+
+func New(config Config) (Mailer, error) {
+	switch config.Transport {
+	case TransportSMTP:
+		return NewSMTPMailer(config), nil
+	case TransportLog, "":
+		if config.LogFile == "" {
+			return NewLogMailer(os.Stdout), nil
+		}
+		return NewFileMailer(config.LogFile)
+	case TransportMemory:
+		return NewMemoryMailer(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail transport %q", config.Transport)
+	}
+}
@@ -0,0 +1,49 @@
+package mailer
+
+import "sync"
+
+// MemoryMailer keeps every sent message in an outbox so tests can read them.
+type MemoryMailer struct {
+	mu     sync.Mutex
+	outbox []Message
+}
+
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+func (m *MemoryMailer) Send(msg Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.outbox = append(m.outbox, msg)
+	return nil
+}
+
+// Outbox returns a copy of the sent messages, oldest first.
+func (m *MemoryMailer) Outbox() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Message(nil), m.outbox...)
+}
+
+// Last returns the most recent message sent to the address.
+func (m *MemoryMailer) Last(to string) (Message, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := len(m.outbox) - 1; i >= 0; i-- {
+		if m.outbox[i].To == to {
+			return m.outbox[i], true
+		}
+	}
+	return Message{}, false
+}
+
+func (m *MemoryMailer) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.outbox = nil
+}
@@ -0,0 +1,280 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID = "blog-client"
+	testNonce    = "nonce-1"
+	testVerifier = "verifier-of-the-test-with-enough-entropy-1234567"
+)
+
+// testProvider is a local OpenID provider: it serves the discovery document,
+// its keys and a token endpoint that checks the PKCE verifier and answers
+// the ID token made by idToken.
+type testProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu        sync.Mutex
+	challenge string
+	idToken   func(claims jwt.MapClaims) string
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tp := &testProvider{t: t, key: key}
+	tp.idToken = func(claims jwt.MapClaims) string { return tp.sign(tp.key, claims) }
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, discovery{
+			Issuer:                tp.server.URL,
+			AuthorizationEndpoint: tp.server.URL + "/authorize",
+			TokenEndpoint:         tp.server.URL + "/token",
+			JWKSURI:               tp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("GET /jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string][]jwk{"keys": {{
+			Kid: "key-1",
+			Kty: "RSA",
+			Alg: "RS256",
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		tp.mu.Lock()
+		challenge := tp.challenge
+		tp.mu.Unlock()
+
+		if r.PostFormValue("code") != "code-1" || r.PostFormValue("client_id") != testClientID {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		if codeChallenge(r.PostFormValue("code_verifier")) != challenge {
+			http.Error(w, `{"error":"invalid_grant","error_description":"PKCE verification failed"}`, http.StatusBadRequest)
+			return
+		}
+
+		writeJSON(w, map[string]string{
+			"access_token": "access-1",
+			"token_type":   "Bearer",
+			"id_token":     tp.idToken(tp.claims()),
+		})
+	})
+
+	tp.server = httptest.NewServer(mux)
+	t.Cleanup(tp.server.Close)
+	return tp
+}
+
+// claims are valid ID token claims for the test client.
+func (tp *testProvider) claims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            tp.server.URL,
+		"sub":            "user-1",
+		"aud":            testClientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          testNonce,
+		"email":          "reader@blog.io",
+		"email_verified": true,
+		"name":           "Reader",
+	}
+}
+
+func (tp *testProvider) sign(key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "key-1"
+	signed, err := token.SignedString(key)
+	if err != nil {
+		tp.t.Fatal(err)
+	}
+	return signed
+}
+
+// login goes through AuthCodeURL, as the browser would, then exchanges the
+// code.
+func (tp *testProvider) login(verifier string) (*Claims, error) {
+	tp.t.Helper()
+
+	provider := NewProvider(Config{
+		Name:         "test",
+		Issuer:       tp.server.URL,
+		ClientID:     testClientID,
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/callback",
+	}, tp.server.Client())
+
+	ctx := context.Background()
+	authURL, err := provider.AuthCodeURL(ctx, "state-1", testNonce, testVerifier)
+	if err != nil {
+		tp.t.Fatal(err)
+	}
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		tp.t.Fatal(err)
+	}
+	query := parsed.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("nonce") != testNonce {
+		tp.t.Fatalf("authorization URL %s lacks PKCE or nonce", authURL)
+	}
+
+	tp.mu.Lock()
+	tp.challenge = query.Get("code_challenge")
+	tp.mu.Unlock()
+
+	return provider.Exchange(ctx, "code-1", verifier, testNonce)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func TestExchange(t *testing.T) {
+	tp := newTestProvider(t)
+
+	claims, err := tp.login(testVerifier)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Claims{
+		Issuer:        tp.server.URL,
+		Subject:       "user-1",
+		Email:         "reader@blog.io",
+		EmailVerified: true,
+		Name:          "Reader",
+	}
+	if *claims != want {
+		t.Fatalf("claims = %+v, want %+v", *claims, want)
+	}
+}
+
+func TestExchangeRejectsInvalidIDToken(t *testing.T) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		idToken func(tp *testProvider, claims jwt.MapClaims) string
+	}{
+		{"signature", func(tp *testProvider, claims jwt.MapClaims) string {
+			return tp.sign(otherKey, claims)
+		}},
+		{"issuer", func(tp *testProvider, claims jwt.MapClaims) string {
+			claims["iss"] = "https://attacker.example"
+			return tp.sign(tp.key, claims)
+		}},
+		{"audience", func(tp *testProvider, claims jwt.MapClaims) string {
+			claims["aud"] = "another-client"
+			return tp.sign(tp.key, claims)
+		}},
+		{"nonce", func(tp *testProvider, claims jwt.MapClaims) string {
+			claims["nonce"] = "replayed-nonce"
+			return tp.sign(tp.key, claims)
+		}},
+		{"expired", func(tp *testProvider, claims jwt.MapClaims) string {
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			return tp.sign(tp.key, claims)
+		}},
+		{"unsigned", func(tp *testProvider, claims jwt.MapClaims) string {
+			signed, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return signed
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tp := newTestProvider(t)
+			tp.idToken = func(claims jwt.MapClaims) string { return test.idToken(tp, claims) }
+
+			if _, err := tp.login(testVerifier); !errors.Is(err, ErrInvalidIDToken) {
+				t.Fatalf("Exchange = %v, want %v", err, ErrInvalidIDToken)
+			}
+		})
+	}
+}
+
+func TestExchangeSendsPKCEVerifier(t *testing.T) {
+	tp := newTestProvider(t)
+
+	// The provider refuses the code when the verifier does not match the
+	// challenge of the authorization URL
+	if _, err := tp.login("another-verifier"); err == nil {
+		t.Fatal("Exchange with a wrong verifier succeeded")
+	}
+
+	if _, err := tp.login(testVerifier); err != nil {
+		t.Fatalf("Exchange with the verifier: %v", err)
+	}
+}
+
+func TestExchangeEmailVerified(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  bool
+	}{
+		{"true", true, true},
+		{"string true", "true", true},
+		{"false", false, false},
+		{"string false", "false", false},
+		{"missing", nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tp := newTestProvider(t)
+			tp.idToken = func(claims jwt.MapClaims) string {
+				if test.value == nil {
+					delete(claims, "email_verified")
+				} else {
+					claims["email_verified"] = test.value
+				}
+				return tp.sign(tp.key, claims)
+			}
+
+			claims, err := tp.login(testVerifier)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if claims.EmailVerified != test.want {
+				t.Fatalf("EmailVerified = %v, want %v", claims.EmailVerified, test.want)
+			}
+		})
+	}
+}
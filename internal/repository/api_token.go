@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"gorm.io/gorm"
+)
+
+type ApiTokenRepository struct {
+	DB *gorm.DB
+}
+
+func NewApiTokenRepository(db *gorm.DB) *ApiTokenRepository {
+	return &ApiTokenRepository{DB: db}
+}
+
+func (tr *ApiTokenRepository) CreateToken(token *entities.ApiToken) error {
+	return tr.DB.Create(token).Error
+}
+
+func (tr *ApiTokenRepository) FindByPrefix(prefix string) (*entities.ApiToken, error) {
+	var token entities.ApiToken
+	err := tr.DB.Where("prefix = ?", prefix).First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindByUser returns the tokens of the user that were not revoked, newest
+// first. Expired tokens are kept so the user knows they must be replaced.
+func (tr *ApiTokenRepository) FindByUser(userId pkg.ULID) ([]entities.ApiToken, error) {
+	var tokens []entities.ApiToken
+	err := tr.DB.
+		Where("user_id = ? AND revoked_at IS NULL", userId).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// RevokeToken revokes a token of the user, it reports false when there was
+// nothing to revoke.
+func (tr *ApiTokenRepository) RevokeToken(userId pkg.ULID, id pkg.ULID, now time.Time) (bool, error) {
+	result := tr.DB.
+		Model(&entities.ApiToken{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userId).
+		Update("revoked_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// TouchLastUsed records the use of a token at most once per interval, so a
+// busy pipeline does not write on every request.
+func (tr *ApiTokenRepository) TouchLastUsed(id pkg.ULID, now time.Time, interval time.Duration) error {
+	return tr.DB.
+		Model(&entities.ApiToken{}).
+		Where("id = ? AND (last_used_at IS NULL OR last_used_at < ?)", id, now.Add(-interval)).
+		Update("last_used_at", now).Error
+}
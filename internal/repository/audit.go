@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"gorm.io/gorm"
+)
+
+type AuditRepository struct {
+	DB *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{DB: db}
+}
+
+func (ar *AuditRepository) CreateEvent(event *entities.AuditEvent) error {
+	return ar.DB.Create(event).Error
+}
+
+// AuditFilter narrows the audit log, empty fields match every event. From is
+// inclusive and To exclusive.
+type AuditFilter struct {
+	ActorID    *pkg.ULID
+	Action     enums.AuditAction
+	TargetType string
+	TargetID   string
+	From       *time.Time
+	To         *time.Time
+}
+
+// ListEvents returns the events newest first. The IDs are ULIDs, sorted by
+// creation time, so the page after is the events with an ID lower than the
+// last one (before), nil for the first page.
+func (ar *AuditRepository) ListEvents(filter AuditFilter, before *pkg.ULID, limit int) ([]entities.AuditEvent, error) {
+	var events []entities.AuditEvent
+
+	query := ar.DB.Model(&entities.AuditEvent{})
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	if before != nil {
+		query = query.Where("id < ?", *before)
+	}
+
+	err := query.
+		Order("id DESC").
+		Limit(limit).
+		Find(&events).Error
+
+	return events, err
+}
+
+// DeleteEventsBefore prunes the events created before cutoff and returns how
+// many were deleted.
+func (ar *AuditRepository) DeleteEventsBefore(cutoff time.Time) (int64, error) {
+	result := ar.DB.
+		Where("created_at < ?", cutoff).
+		Delete(&entities.AuditEvent{})
+
+	return result.RowsAffected, result.Error
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
 	"github.com/clemilsonazevedo/blog/pkg"
 	"gorm.io/gorm"
 )
@@ -21,7 +22,7 @@ func NewCommentRepository(db *gorm.DB) *CommentRepository {
 func (cr *CommentRepository) CreateComment(comment *Comment) error {
 	var existingPost entities.Post
 	if err := cr.DB.
-		Where("id = ?", comment.PostID.String()).
+		Where("id = ? AND status = ?", comment.PostID.String(), enums.Published).
 		First(&existingPost).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return gorm.ErrRecordNotFound
@@ -36,13 +37,155 @@ func (cr *CommentRepository) UpdateComment(comment *Comment) error {
 	return cr.DB.Save(comment).Error
 }
 
+// DeleteComment removes the comment, or turns it into a tombstone when it
+// still has replies so the thread keeps its shape. A tombstone whose last
+// reply is deleted is removed as well.
 func (cr *CommentRepository) DeleteComment(id pkg.ULID) error {
-	return cr.DB.Delete(&Comment{}, id).Error
+	return cr.DB.Transaction(func(tx *gorm.DB) error {
+		return deleteComment(tx, id)
+	})
+}
+
+// ModerateComment deletes the comment like DeleteComment and records who
+// removed it and why in the same transaction.
+func (cr *CommentRepository) ModerateComment(id pkg.ULID, moderation *entities.CommentModeration) error {
+	return cr.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(moderation).Error; err != nil {
+			return err
+		}
+
+		return deleteComment(tx, id)
+	})
+}
+
+func deleteComment(tx *gorm.DB, id pkg.ULID) error {
+	for {
+		var comment Comment
+		if err := tx.Where("id = ?", id).First(&comment).Error; err != nil {
+			return err
+		}
+
+		var replies int64
+		if err := tx.Model(&Comment{}).Where("parent_id = ?", id).Count(&replies).Error; err != nil {
+			return err
+		}
+
+		if replies > 0 {
+			if comment.IsDeleted {
+				return nil
+			}
+
+			return tx.Model(&comment).Updates(map[string]any{
+				"content":    entities.DeletedCommentContent,
+				"is_deleted": true,
+			}).Error
+		}
+
+		if err := tx.Where("id = ?", id).Delete(&Comment{}).Error; err != nil {
+			return err
+		}
+
+		if comment.ParentID == nil {
+			return nil
+		}
+
+		var parent Comment
+		err := tx.Where("id = ? AND is_deleted = ?", *comment.ParentID, true).First(&parent).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		id = parent.ID
+	}
+}
+
+// GetCommentPost returns the published post comments are written on, the
+// other posts cannot be commented.
+func (cr *CommentRepository) GetCommentPost(postID pkg.ULID) (*Post, error) {
+	var post Post
+	err := cr.DB.
+		Select("id", "author_id", "require_comment_approval").
+		Where("id = ? AND status = ?", postID, enums.Published).
+		First(&post).Error
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// GetModeratedPost returns the post of comments being moderated, whatever
+// its status: the queue of an archived post can still be reviewed.
+func (cr *CommentRepository) GetModeratedPost(postID pkg.ULID) (*Post, error) {
+	var post Post
+	err := cr.DB.Select("id", "author_id", "require_comment_approval").Where("id = ?", postID).First(&post).Error
+	if err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+func (cr *CommentRepository) SetPostCommentApproval(postID pkg.ULID, required bool) error {
+	tx := cr.DB.Model(&Post{}).Where("id = ?", postID).Update("require_comment_approval", required)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CountReviewedByUser counts the comments of a user a moderator approved
+// that are still approved. The comments approved without a review, on posts
+// that do not moderate comments, do not count.
+func (cr *CommentRepository) CountReviewedByUser(userID pkg.ULID) (int64, error) {
+	var count int64
+	err := cr.DB.
+		Model(&Comment{}).
+		Where("comments.user_id = ? AND comments.status = ? AND NOT comments.is_deleted", userID, enums.CommentApproved).
+		Where("EXISTS (SELECT 1 FROM comment_moderations WHERE comment_moderations.comment_id = comments.id AND comment_moderations.action = ?)", "approve").
+		Count(&count).Error
+	return count, err
+}
+
+// GetPendingComments lists the moderation queue, only for posts of authorID
+// when it is given.
+func (cr *CommentRepository) GetPendingComments(authorID *pkg.ULID, postID *pkg.ULID) ([]*Comment, error) {
+	query := cr.DB.
+		Model(&Comment{}).
+		Joins("JOIN posts ON posts.id = comments.post_id").
+		Where("comments.status = ?", enums.CommentPending)
+	if authorID != nil {
+		query = query.Where("posts.author_id = ?", *authorID)
+	}
+	if postID != nil {
+		query = query.Where("comments.post_id = ?", *postID)
+	}
+
+	var comments []*Comment
+	err := query.Order("comments.created_at ASC").Find(&comments).Error
+	if err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+func (cr *CommentRepository) ReviewComment(comment *Comment, moderation *entities.CommentModeration) error {
+	return cr.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(comment).Update("status", comment.Status).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(moderation).Error
+	})
 }
 
 func (cr *CommentRepository) GetCommentByID(id pkg.ULID) (*Comment, error) {
 	var comment Comment
-	err := cr.DB.First(&comment, id).Error
+	err := cr.DB.Where("id = ?", id).First(&comment).Error
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +202,7 @@ func (cr *CommentRepository) GetAllComments() ([]*Comment, error) {
 }
 
 func (cr *CommentRepository) GetCommentsByPostID(postID pkg.ULID) ([]*Comment, error) {
-	err := cr.DB.Where("id = ?", postID).First(&Post{}).Error
+	err := cr.DB.Where("id = ? AND status = ?", postID, enums.Published).First(&Post{}).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, gorm.ErrRecordNotFound
@@ -68,7 +211,7 @@ func (cr *CommentRepository) GetCommentsByPostID(postID pkg.ULID) ([]*Comment, e
 	}
 
 	var comments []*Comment
-	err = cr.DB.Where("post_id = ?", postID).Find(&comments).Error
+	err = cr.DB.Where("post_id = ? AND status = ?", postID, enums.CommentApproved).Order("created_at ASC").Find(&comments).Error
 	if err != nil {
 		return nil, err
 	}
@@ -84,3 +227,38 @@ func (cr *CommentRepository) GetCommentsByUserID(userID pkg.ULID) ([]*Comment, e
 	}
 	return Comments, nil
 }
+
+// EditComment saves the new content and keeps the previous one in the
+// history, with the user who edited it.
+func (cr *CommentRepository) EditComment(comment *Comment, previousContent string, editedBy pkg.ULID) error {
+	return cr.DB.Transaction(func(tx *gorm.DB) error {
+		editId, err := pkg.NewULID()
+		if err != nil {
+			return err
+		}
+
+		err = tx.Create(&entities.CommentEdit{
+			ID:              editId,
+			CommentID:       comment.ID,
+			PreviousContent: previousContent,
+			EditedBy:        editedBy,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.
+			Model(comment).
+			Select("content", "edited_at", "status").
+			Updates(Comment{Content: comment.Content, EditedAt: comment.EditedAt, Status: comment.Status}).Error
+	})
+}
+
+func (cr *CommentRepository) GetCommentEdits(commentID pkg.ULID) ([]entities.CommentEdit, error) {
+	var edits []entities.CommentEdit
+	err := cr.DB.Where("comment_id = ?", commentID).Order("created_at DESC").Find(&edits).Error
+	if err != nil {
+		return nil, err
+	}
+	return edits, nil
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"gorm.io/gorm"
+)
+
+// CreatePasswordResetToken stores a new token and discards the ones the user
+// did not use yet, only the last email sent can reset the password.
+func (ur *UserRepository) CreatePasswordResetToken(token *entities.PasswordResetToken) error {
+	return ur.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Where("user_id = ? AND used_at IS NULL", token.UserID).
+			Delete(&entities.PasswordResetToken{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(token).Error
+	})
+}
+
+// ResetPassword consumes the token and replaces the password of its owner,
+// revoking every session issued before now. It returns
+// gorm.ErrRecordNotFound when the token is unknown, used or expired.
+func (ur *UserRepository) ResetPassword(tokenHash string, passwordHash string, now time.Time) (pkg.ULID, error) {
+	var userId pkg.ULID
+	err := ur.DB.Transaction(func(tx *gorm.DB) error {
+		var token entities.PasswordResetToken
+		err := tx.
+			Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+			First(&token).Error
+		if err != nil {
+			return err
+		}
+
+		// Two requests with the same token: only one marks it as used
+		result := tx.
+			Model(&entities.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL", token.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		err = tx.
+			Model(&entities.User{}).
+			Where("id = ?", token.UserID).
+			Updates(map[string]any{
+				"password":            passwordHash,
+				"sessions_revoked_at": now,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := revokeUserSessions(tx, token.UserID, now); err != nil {
+			return err
+		}
+
+		userId = token.UserID
+		return nil
+	})
+
+	return userId, err
+}
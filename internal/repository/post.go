@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
 	"github.com/clemilsonazevedo/blog/pkg"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -19,16 +22,59 @@ func (pr *PostRepository) CreatePost(Post *entities.Post) error {
 	return pr.DB.Create(Post).Error
 }
 
-func (pr *PostRepository) UpdatePost(Post *entities.Post) error {
-	err := pr.DB.
-		Model(&Post).
-		Select("title", "content", "slug").
-		Updates(entities.Post{Title: Post.Title, Content: Post.Content, Slug: Post.Slug}).Error
-	if err != nil {
-		return err
-	}
-
-	return nil
+// UpdatePost saves the new version of a post and keeps the one it replaces
+// as a revision. previous is reloaded under a lock of the post row, so
+// concurrent updates wait for each other and each one numbers and saves the
+// version it actually replaced.
+func (pr *PostRepository) UpdatePost(Post *entities.Post, previous *entities.Post) error {
+	return pr.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ?", Post.ID).
+			First(previous).Error
+		if err != nil {
+			return err
+		}
+
+		if err := pr.createRevision(tx, previous); err != nil {
+			return err
+		}
+
+		err = tx.
+			Model(&Post).
+			Select("title", "content", "slug", "language").
+			Updates(entities.Post{Title: Post.Title, Content: Post.Content, Slug: Post.Slug, Language: Post.Language}).Error
+		if err != nil {
+			return err
+		}
+
+		if Post.Tags != nil {
+			if err := tx.Model(Post).Association("Tags").Replace(Post.Tags); err != nil {
+				return err
+			}
+		}
+
+		if previous.Slug == Post.Slug {
+			return nil
+		}
+
+		// The new slug is live again, so it must no longer redirect anywhere
+		if err := tx.Where("slug = ?", Post.Slug).Delete(&entities.PostSlugHistory{}).Error; err != nil {
+			return err
+		}
+
+		historyId, err := pkg.NewULID()
+		if err != nil {
+			return err
+		}
+
+		return tx.
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "slug"}},
+				DoUpdates: clause.AssignmentColumns([]string{"post_id", "created_at"}),
+			}).
+			Create(&entities.PostSlugHistory{ID: historyId, Slug: previous.Slug, PostID: Post.ID}).Error
+	})
 }
 
 func (pr *PostRepository) DeletePost(id pkg.ULID) error {
@@ -37,7 +83,7 @@ func (pr *PostRepository) DeletePost(id pkg.ULID) error {
 
 func (pr *PostRepository) GetPostByID(postId pkg.ULID) (*entities.Post, error) {
 	var Post entities.Post
-	tx := pr.DB.Model(&entities.Post{}).Where("id = ?", postId).Clauses(clause.Returning{}).UpdateColumn("views", gorm.Expr("views + ?", 1)).Scan(&Post)
+	tx := pr.DB.Model(&entities.Post{}).Where("id = ? AND status = ?", postId, enums.Published).Clauses(clause.Returning{}).UpdateColumn("views", gorm.Expr("views + ?", 1)).Scan(&Post)
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
@@ -46,28 +92,79 @@ func (pr *PostRepository) GetPostByID(postId pkg.ULID) (*entities.Post, error) {
 		return nil, gorm.ErrRecordNotFound
 	}
 
+	if err := pr.DB.Model(&Post).Association("Tags").Find(&Post.Tags); err != nil {
+		return nil, err
+	}
+
+	return &Post, nil
+}
+
+func (pr *PostRepository) FindPostByID(postId pkg.ULID) (*entities.Post, error) {
+	var Post entities.Post
+	err := pr.DB.Preload("Tags").Where("id = ?", postId).First(&Post).Error
+	if err != nil {
+		return nil, err
+	}
+
 	return &Post, nil
 }
 
+func (pr *PostRepository) FindPostBySlug(slug string) (*entities.Post, error) {
+	var Post entities.Post
+	err := pr.DB.Preload("Tags").Where("slug = ? AND status = ?", slug, enums.Published).First(&Post).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &Post, nil
+}
+
+func (pr *PostRepository) FindCurrentSlug(oldSlug string) (string, error) {
+	var post entities.Post
+	err := pr.DB.
+		Joins("JOIN post_slug_history ON post_slug_history.post_id = posts.id").
+		Where("post_slug_history.slug = ? AND posts.status = ?", oldSlug, enums.Published).
+		First(&post).Error
+	if err != nil {
+		return "", err
+	}
+
+	return post.Slug, nil
+}
+
+func (pr *PostRepository) IncrementViews(postId pkg.ULID) error {
+	return pr.DB.Model(&entities.Post{}).Where("id = ?", postId).UpdateColumn("views", gorm.Expr("views + ?", 1)).Error
+}
+
 func (pr *PostRepository) GetAllPosts() ([]*entities.Post, error) {
 	var Posts []*entities.Post
-	err := pr.DB.Find(&Posts).Error
+	err := pr.DB.Preload("Tags").Find(&Posts).Error
 	if err != nil {
 		return nil, err
 	}
 	return Posts, nil
 }
 
-func (pr *PostRepository) FindAllPaginated(limit, offset int) ([]entities.Post, int64, error) {
+func (pr *PostRepository) FindAllPaginated(limit, offset int, tagSlug string) ([]entities.Post, int64, error) {
 	var posts []entities.Post
 	var total int64
 
-	if err := pr.DB.Model(&entities.Post{}).Count(&total).Error; err != nil {
+	query := pr.DB.Model(&entities.Post{}).Where("posts.status = ?", enums.Published)
+	if tagSlug != "" {
+		query = query.
+			Joins("JOIN post_tags ON post_tags.post_id = posts.id").
+			Joins("JOIN tags ON tags.id = post_tags.tag_id").
+			Where("tags.slug = ?", tagSlug)
+	}
+	query = query.Session(&gorm.Session{})
+
+	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := pr.DB.
-		Order("created_at DESC").
+	err := query.
+		Preload("Tags").
+		Order("posts.created_at DESC").
 		Limit(limit).
 		Offset(offset).
 		Find(&posts).Error
@@ -75,6 +172,40 @@ func (pr *PostRepository) FindAllPaginated(limit, offset int) ([]entities.Post,
 	return posts, total, err
 }
 
+func (pr *PostRepository) FindPostsByAuthorAndStatus(authorId pkg.ULID, statuses []enums.PostStatus) ([]entities.Post, error) {
+	var posts []entities.Post
+	err := pr.DB.
+		Preload("Tags").
+		Where("author_id = ? AND status IN ?", authorId, statuses).
+		Order("created_at DESC").
+		Find(&posts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return posts, nil
+}
+
+func (pr *PostRepository) UpdateStatus(Post *entities.Post) error {
+	return pr.DB.
+		Model(&Post).
+		Select("status", "published_at", "scheduled_for").
+		Updates(entities.Post{Status: Post.Status, PublishedAt: Post.PublishedAt, ScheduledFor: Post.ScheduledFor}).Error
+}
+
+func (pr *PostRepository) PublishScheduled(now time.Time) (int64, error) {
+	tx := pr.DB.
+		Model(&entities.Post{}).
+		Where("status = ? AND scheduled_for <= ?", enums.Scheduled, now).
+		Updates(map[string]any{
+			"status":        enums.Published,
+			"published_at":  gorm.Expr("scheduled_for"),
+			"scheduled_for": nil,
+		})
+
+	return tx.RowsAffected, tx.Error
+}
+
 func (r *PostRepository) SlugExists(slug string) (bool, error) {
 	var count int64
 	err := r.DB.Model(&entities.Post{}).
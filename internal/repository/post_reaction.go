@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func reactionColumn(reaction enums.Reaction) string {
+	if reaction == enums.Dislike {
+		return "dislikes"
+	}
+	return "likes"
+}
+
+// lockPublishedPost serializes every reaction change of a post, so the
+// likes/dislikes counters always match the post_reactions rows.
+func lockPublishedPost(tx *gorm.DB, postId pkg.ULID) (*entities.Post, error) {
+	var post entities.Post
+	err := tx.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND status = ?", postId, enums.Published).
+		First(&post).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &post, nil
+}
+
+func (pr *PostRepository) SetReaction(userId, postId pkg.ULID, reaction enums.Reaction) (*entities.Post, error) {
+	var post *entities.Post
+	err := pr.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		post, err = lockPublishedPost(tx, postId)
+		if err != nil {
+			return err
+		}
+
+		var existing entities.PostReaction
+		err = tx.Where("user_id = ? AND post_id = ?", userId, postId).First(&existing).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if err == nil {
+			if existing.Reaction == reaction {
+				return nil
+			}
+
+			if err := tx.Model(&existing).Update("reaction", reaction).Error; err != nil {
+				return err
+			}
+
+			previous := reactionColumn(existing.Reaction)
+			if err := tx.Model(post).UpdateColumn(previous, gorm.Expr(previous+" - 1")).Error; err != nil {
+				return err
+			}
+		} else {
+			err := tx.Create(&entities.PostReaction{UserID: userId, PostID: postId, Reaction: reaction}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		current := reactionColumn(reaction)
+		if err := tx.Model(post).UpdateColumn(current, gorm.Expr(current+" + 1")).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", postId).First(post).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (pr *PostRepository) RemoveReaction(userId, postId pkg.ULID) (*entities.Post, error) {
+	var post *entities.Post
+	err := pr.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		post, err = lockPublishedPost(tx, postId)
+		if err != nil {
+			return err
+		}
+
+		var existing entities.PostReaction
+		err = tx.
+			Clauses(clause.Returning{}).
+			Where("user_id = ? AND post_id = ?", userId, postId).
+			Delete(&existing).Error
+		if err != nil {
+			return err
+		}
+
+		if existing.Reaction == "" {
+			return nil
+		}
+
+		column := reactionColumn(existing.Reaction)
+		if err := tx.Model(post).UpdateColumn(column, gorm.Expr(column+" - 1")).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", postId).First(post).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (pr *PostRepository) FindReaction(userId, postId pkg.ULID) (enums.Reaction, error) {
+	var reaction entities.PostReaction
+	err := pr.DB.Where("user_id = ? AND post_id = ?", userId, postId).First(&reaction).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return reaction.Reaction, nil
+}
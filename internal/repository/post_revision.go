@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"gorm.io/gorm"
+)
+
+// createRevision must run with the post row locked, the next revision number
+// is read from the existing ones.
+func (pr *PostRepository) createRevision(tx *gorm.DB, previous *entities.Post) error {
+	var last int
+	err := tx.
+		Model(&entities.PostRevision{}).
+		Where("post_id = ?", previous.ID).
+		Select("COALESCE(MAX(revision), 0)").
+		Scan(&last).Error
+	if err != nil {
+		return err
+	}
+
+	revisionId, err := pkg.NewULID()
+	if err != nil {
+		return err
+	}
+
+	return tx.Create(&entities.PostRevision{
+		ID:       revisionId,
+		PostID:   previous.ID,
+		Revision: last + 1,
+		Title:    previous.Title,
+		Slug:     previous.Slug,
+		Content:  previous.Content,
+	}).Error
+}
+
+func (pr *PostRepository) GetRevisionsByPostID(postId pkg.ULID) ([]entities.PostRevision, error) {
+	var revisions []entities.PostRevision
+	err := pr.DB.
+		Where("post_id = ?", postId).
+		Order("revision DESC").
+		Find(&revisions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (pr *PostRepository) GetRevision(postId pkg.ULID, revision int) (*entities.PostRevision, error) {
+	var postRevision entities.PostRevision
+	err := pr.DB.
+		Where("post_id = ? AND revision = ?", postId, revision).
+		First(&postRevision).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return &postRevision, nil
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"gorm.io/gorm"
+)
+
+// Both configurations are queried so a search matches posts written in
+// either language while still being able to use idx_posts_search_vector.
+var searchTsQuery = fmt.Sprintf("(websearch_to_tsquery('%s', @query) || websearch_to_tsquery('%s', @query))",
+	enums.Portuguese.SearchConfig(), enums.English.SearchConfig())
+
+// ts_headline copies the content as is, so the matches are marked with
+// private use characters and the snippet is escaped before they become
+// <mark> tags.
+const (
+	searchStartSel = "\uE000"
+	searchStopSel  = "\uE001"
+)
+
+var searchHeadlineOptions = fmt.Sprintf("StartSel=%s, StopSel=%s, MaxWords=35, MinWords=15, MaxFragments=2", searchStartSel, searchStopSel)
+
+var snippetMarks = strings.NewReplacer(searchStartSel, "<mark>", searchStopSel, "</mark>")
+
+type PostSearchResult struct {
+	ID          pkg.ULID
+	Title       string
+	Slug        string
+	Language    enums.Language
+	AuthorId    pkg.ULID
+	CreatedAt   time.Time
+	PublishedAt *time.Time
+	Rank        float64
+	// Snippet is HTML: the content is escaped and the matches are wrapped
+	// in <mark>
+	Snippet string
+}
+
+func (pr *PostRepository) SearchPosts(query string, limit, offset int) ([]PostSearchResult, int64, error) {
+	var results []PostSearchResult
+	var total int64
+
+	args := map[string]any{"query": query, "headline": searchHeadlineOptions}
+	base := pr.DB.
+		Model(&entities.Post{}).
+		Where("posts.status = ?", enums.Published).
+		Where("posts.search_vector @@ "+searchTsQuery, args).
+		Session(&gorm.Session{})
+
+	if err := base.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := base.
+		Select(
+			"posts.id, posts.title, posts.slug, posts.language, posts.author_id, posts.created_at, posts.published_at, "+
+				"ts_rank(posts.search_vector, "+searchTsQuery+") AS rank, "+
+				"ts_headline("+enums.SearchConfigSQL("posts.language")+", "+
+				"posts.content, "+searchTsQuery+", @headline) AS snippet",
+			args,
+		).
+		Order("rank DESC, posts.created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(&results).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range results {
+		results[i].Snippet = highlightSnippet(results[i].Snippet)
+	}
+	return results, total, nil
+}
+
+// highlightSnippet escapes a ts_headline snippet and turns its match markers
+// into <mark> tags.
+func highlightSnippet(snippet string) string {
+	return snippetMarks.Replace(html.EscapeString(snippet))
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RateLimitRepository struct {
+	DB *gorm.DB
+}
+
+func NewRateLimitRepository(db *gorm.DB) *RateLimitRepository {
+	return &RateLimitRepository{DB: db}
+}
+
+// UpdateBucket locks the bucket of key and saves it after update. A new
+// bucket has no tokens and a zero RefilledAt, so it is refilled completely.
+func (rr *RateLimitRepository) UpdateBucket(key string, update func(bucket *entities.RateLimitBucket)) error {
+	return rr.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&entities.RateLimitBucket{Key: key}).Error
+		if err != nil {
+			return err
+		}
+
+		var bucket entities.RateLimitBucket
+		err = tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("key = ?", key).
+			First(&bucket).Error
+		if err != nil {
+			return err
+		}
+
+		update(&bucket)
+		return tx.Save(&bucket).Error
+	})
+}
+
+// DeleteFullBuckets removes the buckets refilled before now, they would
+// start full anyway.
+func (rr *RateLimitRepository) DeleteFullBuckets(now time.Time) (int64, error) {
+	result := rr.DB.
+		Where("full_at < ?", now).
+		Delete(&entities.RateLimitBucket{})
+
+	return result.RowsAffected, result.Error
+}
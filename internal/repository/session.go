@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"gorm.io/gorm"
+)
+
+type SessionRepository struct {
+	DB *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) *SessionRepository {
+	return &SessionRepository{DB: db}
+}
+
+func (sr *SessionRepository) CreateSession(session *entities.Session) error {
+	return sr.DB.Create(session).Error
+}
+
+// FindByTokenHash returns the session of a refresh token, rotated and
+// revoked ones included.
+func (sr *SessionRepository) FindByTokenHash(tokenHash string) (*entities.Session, error) {
+	var session entities.Session
+	err := sr.DB.Where("token_hash = ?", tokenHash).First(&session).Error
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Rotate marks the current refresh token as used and stores the next one of
+// the family. It reports false when the token was rotated or revoked in the
+// meantime (two refreshes racing with the same token).
+func (sr *SessionRepository) Rotate(current *entities.Session, next *entities.Session, now time.Time) (bool, error) {
+	rotated := false
+	err := sr.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&entities.Session{}).
+			Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", current.ID).
+			Update("rotated_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+
+		rotated = true
+		return nil
+	})
+
+	return rotated, err
+}
+
+// IsActive reports whether the family of the user still has a usable refresh
+// token.
+func (sr *SessionRepository) IsActive(userId pkg.ULID, familyId pkg.ULID, now time.Time) (bool, error) {
+	var count int64
+	err := sr.DB.
+		Model(&entities.Session{}).
+		Where("user_id = ? AND family_id = ? AND rotated_at IS NULL AND revoked_at IS NULL AND expires_at > ?", userId, familyId, now).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// FindActiveByUser returns the current token of every active family of the
+// user, most recently used first.
+func (sr *SessionRepository) FindActiveByUser(userId pkg.ULID, now time.Time) ([]entities.Session, error) {
+	var sessions []entities.Session
+	err := sr.DB.
+		Where("user_id = ? AND rotated_at IS NULL AND revoked_at IS NULL AND expires_at > ?", userId, now).
+		Order("created_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (sr *SessionRepository) RevokeFamily(familyId pkg.ULID, now time.Time) error {
+	return sr.DB.
+		Model(&entities.Session{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyId).
+		Update("revoked_at", now).Error
+}
+
+// RevokeUserFamily revokes a family only if it belongs to the user, it
+// reports false when there was nothing to revoke.
+func (sr *SessionRepository) RevokeUserFamily(userId pkg.ULID, familyId pkg.ULID, now time.Time) (bool, error) {
+	result := sr.DB.
+		Model(&entities.Session{}).
+		Where("user_id = ? AND family_id = ? AND revoked_at IS NULL", userId, familyId).
+		Update("revoked_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (sr *SessionRepository) RevokeAllByUser(userId pkg.ULID, now time.Time) error {
+	return revokeUserSessions(sr.DB, userId, now)
+}
+
+func revokeUserSessions(db *gorm.DB, userId pkg.ULID, now time.Time) error {
+	return db.
+		Model(&entities.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userId).
+		Update("revoked_at", now).Error
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"github.com/gosimple/slug"
+	"gorm.io/gorm"
+)
+
+type TagWithCount struct {
+	ID        pkg.ULID
+	Name      string
+	Slug      string
+	PostCount int64
+}
+
+type TagRepository struct {
+	DB *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) *TagRepository {
+	return &TagRepository{DB: db}
+}
+
+func (tr *TagRepository) FindOrCreateByNames(names []string) ([]entities.Tag, error) {
+	tags := []entities.Tag{}
+	for _, name := range names {
+		tagSlug := slug.Make(name)
+		if tagSlug == "" {
+			continue
+		}
+
+		tagId, err := pkg.NewULID()
+		if err != nil {
+			return nil, err
+		}
+
+		var tag entities.Tag
+		err = tr.DB.
+			Where("slug = ?", tagSlug).
+			Attrs(entities.Tag{ID: tagId, Name: name, Slug: tagSlug}).
+			FirstOrCreate(&tag).Error
+		if err != nil {
+			return nil, err
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+func (tr *TagRepository) GetAllWithPostCount() ([]TagWithCount, error) {
+	var tags []TagWithCount
+	err := tr.DB.
+		Model(&entities.Tag{}).
+		Select("tags.id, tags.name, tags.slug, COUNT(posts.id) AS post_count").
+		Joins("LEFT JOIN post_tags ON post_tags.tag_id = tags.id").
+		Joins("LEFT JOIN posts ON posts.id = post_tags.post_id AND posts.status = ?", enums.Published).
+		Group("tags.id").
+		Order("post_count DESC, tags.name ASC").
+		Scan(&tags).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tags, nil
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/clemilsonazevedo/blog/internal/domain/entities"
 	"github.com/clemilsonazevedo/blog/pkg"
 	"gorm.io/gorm"
@@ -55,3 +57,74 @@ func (ur *UserRepository) GetUserByEmail(email string) (*entities.User, error) {
 
 	return &user, nil
 }
+
+// MarkEmailVerified sets the verification date only once, it reports false
+// when the email of the user was already verified.
+func (ur *UserRepository) MarkEmailVerified(id pkg.ULID, at time.Time) (bool, error) {
+	result := ur.DB.
+		Model(&entities.User{}).
+		Where("id = ? AND email_verified_at IS NULL", id).
+		Update("email_verified_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// UpdatePassword replaces the password hash and revokes the sessions issued
+// before revokeSessionsAt.
+func (ur *UserRepository) UpdatePassword(id pkg.ULID, passwordHash string, revokeSessionsAt time.Time) error {
+	return ur.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Model(&entities.User{}).
+			Where("id = ?", id).
+			Updates(map[string]any{
+				"password":            passwordHash,
+				"sessions_revoked_at": revokeSessionsAt,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return revokeUserSessions(tx, id, revokeSessionsAt)
+	})
+}
+
+// ChangeEmail switches the email of the user from one address to another,
+// the new one is verified since it was confirmed by email. The sessions
+// issued before at are revoked. It reports false when the email of the user
+// is no longer from (the change was already done).
+func (ur *UserRepository) ChangeEmail(id pkg.ULID, from string, to string, at time.Time) (bool, error) {
+	changed := false
+	err := ur.DB.Transaction(func(tx *gorm.DB) error {
+		result := tx.
+			Model(&entities.User{}).
+			Where("id = ? AND email = ?", id, from).
+			Updates(map[string]any{
+				"email":               to,
+				"email_verified_at":   at,
+				"sessions_revoked_at": at,
+			})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		changed = true
+
+		return revokeUserSessions(tx, id, at)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return changed, nil
+}
+
+// UpdatePasswordHash replaces the hash of the same password, upgraded to a
+// stronger algorithm, so the sessions are kept.
+func (ur *UserRepository) UpdatePasswordHash(id pkg.ULID, from string, to string) error {
+	return ur.DB.
+		Model(&entities.User{}).
+		Where("id = ? AND password = ?", id, from).
+		Update("password", to).Error
+}
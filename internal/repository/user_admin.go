@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"gorm.io/gorm"
+)
+
+// UserFilter narrows the user list of the admins, empty fields match every
+// user. Query matches the username or the email.
+type UserFilter struct {
+	Query  string
+	Role   enums.Role
+	Status enums.UserStatus
+}
+
+func (ur *UserRepository) SearchUsers(filter UserFilter, limit, offset int, now time.Time) ([]entities.User, int64, error) {
+	var users []entities.User
+	var total int64
+
+	query := ur.DB.Model(&entities.User{})
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		query = query.Where("username ILIKE ? OR email ILIKE ?", pattern, pattern)
+	}
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	switch filter.Status {
+	case enums.UserActive:
+		query = query.Where("banned_at IS NULL AND (suspended_until IS NULL OR suspended_until <= ?)", now)
+	case enums.UserSuspended:
+		query = query.Where("banned_at IS NULL AND suspended_until > ?", now)
+	case enums.UserBanned:
+		query = query.Where("banned_at IS NOT NULL")
+	}
+	query = query.Session(&gorm.Session{})
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&users).Error
+
+	return users, total, err
+}
+
+func (ur *UserRepository) UpdateRole(id pkg.ULID, role enums.Role) error {
+	return ur.DB.
+		Model(&entities.User{}).
+		Where("id = ?", id).
+		Update("role", role).Error
+}
+
+// SetAccountStatus suspends, bans or reactivates a user. Suspending and
+// banning also end every session of the user.
+func (ur *UserRepository) SetAccountStatus(id pkg.ULID, suspendedUntil *time.Time, bannedAt *time.Time, reason string, now time.Time) error {
+	return ur.DB.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]any{
+			"suspended_until": suspendedUntil,
+			"banned_at":       bannedAt,
+			"status_reason":   reason,
+		}
+		blocked := suspendedUntil != nil || bannedAt != nil
+		if blocked {
+			updates["sessions_revoked_at"] = now
+		}
+
+		err := tx.
+			Model(&entities.User{}).
+			Where("id = ?", id).
+			Updates(updates).Error
+		if err != nil {
+			return err
+		}
+
+		if !blocked {
+			return nil
+		}
+		return revokeUserSessions(tx, id, now)
+	})
+}
+
+// RevokeSessions logs the user out of every device.
+func (ur *UserRepository) RevokeSessions(id pkg.ULID, at time.Time) error {
+	return ur.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Model(&entities.User{}).
+			Where("id = ?", id).
+			Update("sessions_revoked_at", at).Error
+		if err != nil {
+			return err
+		}
+
+		return revokeUserSessions(tx, id, at)
+	})
+}
+
+// escapeLike makes the wildcards typed by the admin match literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"gorm.io/gorm"
+)
+
+func (ur *UserRepository) GetIdentity(issuer string, subject string) (*entities.UserIdentity, error) {
+	var identity entities.UserIdentity
+	err := ur.DB.
+		Where("issuer = ? AND subject = ?", issuer, subject).
+		First(&identity).Error
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (ur *UserRepository) CreateIdentity(identity *entities.UserIdentity) error {
+	return ur.DB.Create(identity).Error
+}
+
+// CreateUserWithIdentity registers a user coming from a provider, the user
+// is never created without its identity.
+func (ur *UserRepository) CreateUserWithIdentity(user *entities.User, identity *entities.UserIdentity) error {
+	return ur.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		return tx.Create(identity).Error
+	})
+}
+
+// TouchIdentity keeps the email of the provider up to date on each login.
+func (ur *UserRepository) TouchIdentity(id pkg.ULID, email string, at time.Time) error {
+	return ur.DB.
+		Model(&entities.UserIdentity{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"email":         email,
+			"last_login_at": at,
+		}).Error
+}
+
+func (ur *UserRepository) UserNameExists(username string) (bool, error) {
+	var count int64
+	err := ur.DB.
+		Model(&entities.User{}).
+		Where("username = ?", username).
+		Count(&count).Error
+	return count > 0, err
+}
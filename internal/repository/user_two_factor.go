@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"gorm.io/gorm"
+)
+
+// SetTOTPSecret starts an enrollment, two factor stays disabled until
+// EnableTwoFactor.
+func (ur *UserRepository) SetTOTPSecret(id pkg.ULID, secret string) error {
+	return ur.DB.
+		Model(&entities.User{}).
+		Where("id = ? AND two_factor_enabled_at IS NULL", id).
+		Update("totp_secret", secret).Error
+}
+
+// EnableTwoFactor turns two factor on and replaces the recovery codes.
+func (ur *UserRepository) EnableTwoFactor(id pkg.ULID, step int64, at time.Time, codes []entities.RecoveryCode) error {
+	return ur.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Model(&entities.User{}).
+			Where("id = ?", id).
+			Updates(map[string]any{
+				"two_factor_enabled_at": at,
+				"totp_last_step":        step,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", id).Delete(&entities.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&codes).Error
+	})
+}
+
+func (ur *UserRepository) DisableTwoFactor(id pkg.ULID) error {
+	return ur.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Model(&entities.User{}).
+			Where("id = ?", id).
+			Updates(map[string]any{
+				"totp_secret":           "",
+				"totp_last_step":        0,
+				"two_factor_enabled_at": nil,
+			}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", id).Delete(&entities.RecoveryCode{}).Error
+	})
+}
+
+// UseTOTPStep records the step of a code used to login. It reports false
+// when this step (or a later one) was already used.
+func (ur *UserRepository) UseTOTPStep(id pkg.ULID, step int64) (bool, error) {
+	result := ur.DB.
+		Model(&entities.User{}).
+		Where("id = ? AND totp_last_step < ?", id, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UseRecoveryCode spends a recovery code, it reports false when the code is
+// unknown or was already used.
+func (ur *UserRepository) UseRecoveryCode(userId pkg.ULID, codeHash string, at time.Time) (bool, error) {
+	result := ur.DB.
+		Model(&entities.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userId, codeHash).
+		Update("used_at", at)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
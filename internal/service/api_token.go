@@ -0,0 +1,132 @@
+package service
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/http/auth"
+	"github.com/clemilsonazevedo/blog/internal/repository"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidApiToken  = errors.New("invalid, expired or revoked api token")
+	ErrInvalidApiScope  = errors.New("invalid api token scope")
+	ErrInvalidExpiry    = errors.New("api token expiry must be in the future")
+	ErrApiTokenNotFound = errors.New("api token not found")
+)
+
+type ApiTokenService struct {
+	apiTokenRepository *repository.ApiTokenRepository
+	userRepository     *repository.UserRepository
+}
+
+func NewApiTokenService(apiTokenRepository *repository.ApiTokenRepository, userRepository *repository.UserRepository) *ApiTokenService {
+	return &ApiTokenService{
+		apiTokenRepository: apiTokenRepository,
+		userRepository:     userRepository,
+	}
+}
+
+// CreateToken creates a token for the user and returns it with its secret,
+// the only time the secret is available.
+func (ts *ApiTokenService) CreateToken(user *entities.User, name string, scopes []enums.ApiScope, expiresAt *time.Time) (*entities.ApiToken, string, error) {
+	if len(scopes) == 0 {
+		return nil, "", ErrInvalidApiScope
+	}
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, "", ErrInvalidApiScope
+		}
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", ErrInvalidExpiry
+	}
+
+	secret, prefix, secretHash, err := auth.NewApiToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	tokenId, err := pkg.NewULID()
+	if err != nil {
+		return nil, "", err
+	}
+
+	token := entities.ApiToken{
+		ID:         tokenId,
+		UserID:     user.ID,
+		Name:       name,
+		Prefix:     prefix,
+		SecretHash: secretHash,
+		ExpiresAt:  expiresAt,
+	}
+	token.SetScopes(scopes)
+
+	if err := ts.apiTokenRepository.CreateToken(&token); err != nil {
+		return nil, "", err
+	}
+
+	return &token, secret, nil
+}
+
+func (ts *ApiTokenService) GetTokens(userId pkg.ULID) ([]entities.ApiToken, error) {
+	return ts.apiTokenRepository.FindByUser(userId)
+}
+
+func (ts *ApiTokenService) RevokeToken(userId pkg.ULID, id pkg.ULID) error {
+	revoked, err := ts.apiTokenRepository.RevokeToken(userId, id, time.Now())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrApiTokenNotFound
+	}
+	return nil
+}
+
+// Authenticate returns the token and its owner for the secret sent by a
+// client.
+func (ts *ApiTokenService) Authenticate(secret string) (*entities.ApiToken, *entities.User, error) {
+	prefix, ok := auth.SplitApiToken(secret)
+	if !ok {
+		return nil, nil, ErrInvalidApiToken
+	}
+
+	token, err := ts.apiTokenRepository.FindByPrefix(prefix)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidApiToken
+		}
+		return nil, nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(auth.HashOpaqueToken(secret)), []byte(token.SecretHash)) != 1 {
+		return nil, nil, ErrInvalidApiToken
+	}
+
+	now := time.Now()
+	if token.RevokedAt != nil || (token.ExpiresAt != nil && !token.ExpiresAt.After(now)) {
+		return nil, nil, ErrInvalidApiToken
+	}
+
+	user, err := ts.userRepository.GetUserByID(token.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidApiToken
+		}
+		return nil, nil, err
+	}
+
+	if err := ts.apiTokenRepository.TouchLastUsed(token.ID, now, time.Minute); err != nil {
+		log.Printf("api token %s: cannot update last use: %v", token.Prefix, err)
+	}
+
+	return token, user, nil
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/repository"
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+var ErrInvalidAuditCursor = errors.New("invalid audit cursor")
+
+// AuditEntry describes who did what, the request data (IP, user agent and
+// request ID) is filled by the controllers.
+type AuditEntry struct {
+	ActorID    *pkg.ULID
+	Action     enums.AuditAction
+	TargetType string
+	TargetID   string
+
+	IP        string
+	UserAgent string
+	RequestID string
+	Metadata  map[string]any
+}
+
+type AuditService struct {
+	auditRepository *repository.AuditRepository
+}
+
+func NewAuditService(auditRepository *repository.AuditRepository) *AuditService {
+	return &AuditService{
+		auditRepository: auditRepository,
+	}
+}
+
+// Record saves the event. A failure is only logged, the audited action
+// already happened and must not be reported as failed because of it.
+func (as *AuditService) Record(entry AuditEntry) {
+	if err := as.record(entry); err != nil {
+		log.Printf("audit: cannot record %s: %v", entry.Action, err)
+	}
+}
+
+func (as *AuditService) record(entry AuditEntry) error {
+	metadata := []byte("{}")
+	if len(entry.Metadata) > 0 {
+		var err error
+		metadata, err = json.Marshal(entry.Metadata)
+		if err != nil {
+			return err
+		}
+	}
+
+	eventId, err := pkg.NewULID()
+	if err != nil {
+		return err
+	}
+
+	return as.auditRepository.CreateEvent(&entities.AuditEvent{
+		ID:         eventId,
+		ActorID:    entry.ActorID,
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		IP:         entry.IP,
+		UserAgent:  entry.UserAgent,
+		RequestID:  entry.RequestID,
+		Metadata:   string(metadata),
+	})
+}
+
+// ListEvents returns a page of events, newest first, and the cursor of the
+// next page, empty on the last one.
+func (as *AuditService) ListEvents(filter repository.AuditFilter, cursor string, limit int) ([]entities.AuditEvent, string, error) {
+	var before *pkg.ULID
+	if cursor != "" {
+		id, err := pkg.ParseULID(cursor)
+		if err != nil {
+			return nil, "", ErrInvalidAuditCursor
+		}
+		before = &id
+	}
+
+	events, err := as.auditRepository.ListEvents(filter, before, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(events) <= limit {
+		return events, "", nil
+	}
+
+	events = events[:limit]
+	return events, events[limit-1].ID.String(), nil
+}
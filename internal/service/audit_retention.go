@@ -0,0 +1,50 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// StartRetention prunes the events older than retention now, then every
+// interval. An interval of zero or less disables the pruning.
+func (as *AuditService) StartRetention(interval time.Duration, retention time.Duration) error {
+	if interval <= 0 {
+		log.Printf("audit retention: disabled, events are kept forever")
+		return nil
+	}
+	if retention <= 0 {
+		return fmt.Errorf("audit retention must be positive, got %s", retention)
+	}
+
+	go as.runRetention(interval, retention)
+	return nil
+}
+
+func (as *AuditService) runRetention(interval time.Duration, retention time.Duration) {
+	as.pruneExpired(retention)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		as.pruneExpired(retention)
+	}
+}
+
+func (as *AuditService) pruneExpired(retention time.Duration) {
+	pruned, err := as.PruneEvents(time.Now().Add(-retention))
+	if err != nil {
+		log.Printf("audit retention: %v", err)
+		return
+	}
+
+	if pruned > 0 {
+		log.Printf("audit retention: %d old events deleted", pruned)
+	}
+}
+
+// PruneEvents deletes the events created before cutoff.
+func (as *AuditService) PruneEvents(cutoff time.Time) (int64, error) {
+	return as.auditRepository.DeleteEventsBefore(cutoff)
+}
@@ -1,35 +1,235 @@
 package service
 
 import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/authz"
 	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
 	"github.com/clemilsonazevedo/blog/internal/repository"
 	"github.com/clemilsonazevedo/blog/pkg"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidParentComment = errors.New("parent comment does not exist on this post")
+	ErrMaxCommentDepth      = errors.New("maximum reply depth reached")
+	ErrNotCommentOwner      = errors.New("only the owner can edit this comment")
+	ErrCommentEditExpired   = errors.New("the time to edit this comment has expired")
+	ErrCommentDeleted       = errors.New("this comment was deleted")
+	ErrCommentDeleteDenied  = errors.New("only the owner, the post author or a moderator can delete this comment")
+	ErrModerationReason     = errors.New("a reason is required to delete comments of other users")
+	ErrNotPostModerator     = errors.New("only the post author or a moderator can moderate these comments")
+	ErrCommentNotPending    = errors.New("this comment is not waiting for moderation")
 )
 
 type Comment = entities.Comment
 type CommentRepository = repository.CommentRepository
+
+type CommentConfig struct {
+	MaxDepth   int
+	EditWindow time.Duration
+
+	// ModerationEnabled queues comments of every post, otherwise only posts
+	// with RequireCommentApproval are moderated
+	ModerationEnabled bool
+	AutoApproveAfter  int
+}
+
 type CommentService struct {
 	CommentRepository *CommentRepository
+	config            CommentConfig
 }
 
-func NewCommentService(commentRepository *repository.CommentRepository) *CommentService {
+func NewCommentService(commentRepository *repository.CommentRepository, config CommentConfig) *CommentService {
 	return &CommentService{
 		CommentRepository: commentRepository,
+		config:            config,
 	}
 }
 
 func (cs *CommentService) CreateComment(comment *Comment) error {
+	if comment.ParentID != nil {
+		parent, err := cs.CommentRepository.GetCommentByID(*comment.ParentID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidParentComment
+			}
+			return err
+		}
+
+		if parent.PostID != comment.PostID || parent.IsDeleted || parent.Status != enums.CommentApproved {
+			return ErrInvalidParentComment
+		}
+
+		if parent.Depth+1 > cs.config.MaxDepth {
+			return ErrMaxCommentDepth
+		}
+
+		comment.Depth = parent.Depth + 1
+	}
+
+	status, err := cs.initialStatus(comment)
+	if err != nil {
+		return err
+	}
+	comment.Status = status
+
 	return cs.CommentRepository.CreateComment(comment)
 }
 
+func (cs *CommentService) initialStatus(comment *Comment) (enums.CommentStatus, error) {
+	post, err := cs.CommentRepository.GetCommentPost(comment.PostID)
+	if err != nil {
+		return "", err
+	}
+
+	if !cs.config.ModerationEnabled && !post.RequireCommentApproval {
+		return enums.CommentApproved, nil
+	}
+
+	if post.AuthorId == comment.UserID {
+		return enums.CommentApproved, nil
+	}
+
+	if cs.config.AutoApproveAfter > 0 {
+		approved, err := cs.CommentRepository.CountReviewedByUser(comment.UserID)
+		if err != nil {
+			return "", err
+		}
+
+		if approved >= int64(cs.config.AutoApproveAfter) {
+			return enums.CommentApproved, nil
+		}
+	}
+
+	return enums.CommentPending, nil
+}
+
 func (cs *CommentService) UpdateComment(comment *Comment) error {
 	return cs.CommentRepository.UpdateComment(comment)
 }
 
+func (cs *CommentService) EditComment(id pkg.ULID, actor *entities.User, content string) (*Comment, error) {
+	comment, err := cs.CommentRepository.GetCommentByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !authz.Can(actor, authz.CommentEdit, comment) {
+		return nil, ErrNotCommentOwner
+	}
+
+	if comment.IsDeleted {
+		return nil, ErrCommentDeleted
+	}
+
+	if cs.config.EditWindow > 0 && time.Since(comment.CreatedAt) > cs.config.EditWindow {
+		return nil, ErrCommentEditExpired
+	}
+
+	if comment.Content == content {
+		return comment, nil
+	}
+
+	previousContent := comment.Content
+	now := time.Now().UTC()
+	comment.Content = content
+	comment.EditedAt = &now
+
+	// An approved comment cannot be edited into something the moderators
+	// did not see: it goes through the rules of a new comment again, and its
+	// replies are hidden while it waits in the queue
+	if comment.Status == enums.CommentApproved {
+		status, err := cs.initialStatus(comment)
+		if err != nil {
+			return nil, err
+		}
+		comment.Status = status
+	}
+
+	if err := cs.CommentRepository.EditComment(comment, previousContent, actor.ID); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// GetCommentEdits returns the edit history of a comment to the moderators
+// of its post: the post author or a moderator.
+func (cs *CommentService) GetCommentEdits(actor *entities.User, id pkg.ULID) ([]entities.CommentEdit, error) {
+	comment, err := cs.CommentRepository.GetCommentByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := cs.canModeratePost(actor, comment.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowed {
+		return nil, ErrNotPostModerator
+	}
+
+	return cs.CommentRepository.GetCommentEdits(id)
+}
+
 func (cs *CommentService) DeleteComment(id pkg.ULID) error {
 	return cs.CommentRepository.DeleteComment(id)
 }
 
+// DeleteCommentAs deletes a comment on behalf of actor. Owners delete their
+// own comments freely, users allowed to delete any comment or to moderate the
+// post can remove it but must give a reason, which is kept as a moderation
+// record.
+func (cs *CommentService) DeleteCommentAs(actor *entities.User, id pkg.ULID, reason string) error {
+	comment, err := cs.CommentRepository.GetCommentByID(id)
+	if err != nil {
+		return err
+	}
+
+	if comment.UserID == actor.ID && authz.Can(actor, authz.CommentDelete, comment) {
+		return cs.CommentRepository.DeleteComment(id)
+	}
+
+	allowed := authz.Can(actor, authz.CommentDelete, comment)
+	if !allowed {
+		allowed, err = cs.canModeratePost(actor, comment.PostID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !allowed {
+		return ErrCommentDeleteDenied
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrModerationReason
+	}
+
+	moderationId, err := pkg.NewULID()
+	if err != nil {
+		return err
+	}
+
+	return cs.CommentRepository.ModerateComment(id, &entities.CommentModeration{
+		ID:              moderationId,
+		CommentID:       comment.ID,
+		PostID:          comment.PostID,
+		CommentAuthorID: comment.UserID,
+		ModeratorID:     actor.ID,
+		ModeratorRole:   actor.Role,
+		Action:          "delete",
+		Reason:          reason,
+		Content:         comment.Content,
+	})
+}
+
 func (cs *CommentService) GetCommentByID(id pkg.ULID) (*Comment, error) {
 	return cs.CommentRepository.GetCommentByID(id)
 }
@@ -45,3 +245,90 @@ func (cs *CommentService) GetCommentsByPostID(postID pkg.ULID) ([]*Comment, erro
 func (cs *CommentService) GetCommentsByUserID(userID pkg.ULID) ([]*Comment, error) {
 	return cs.CommentRepository.GetCommentsByUserID(userID)
 }
+
+func (cs *CommentService) canModeratePost(actor *entities.User, postID pkg.ULID) (bool, error) {
+	if authz.Can(actor, authz.CommentModerate, nil) {
+		return true, nil
+	}
+
+	post, err := cs.CommentRepository.GetModeratedPost(postID)
+	if err != nil {
+		return false, err
+	}
+
+	return authz.Can(actor, authz.CommentModerate, post), nil
+}
+
+// GetPendingComments returns the moderation queue visible to actor: every
+// pending comment for moderators, the ones on their own posts for authors.
+func (cs *CommentService) GetPendingComments(actor *entities.User, postID *pkg.ULID) ([]*Comment, error) {
+	if authz.Can(actor, authz.CommentModerate, nil) {
+		return cs.CommentRepository.GetPendingComments(nil, postID)
+	}
+
+	return cs.CommentRepository.GetPendingComments(&actor.ID, postID)
+}
+
+func (cs *CommentService) ApproveComment(actor *entities.User, id pkg.ULID, reason string) (*Comment, error) {
+	return cs.reviewComment(actor, id, enums.CommentApproved, "approve", reason)
+}
+
+func (cs *CommentService) RejectComment(actor *entities.User, id pkg.ULID, reason string) (*Comment, error) {
+	return cs.reviewComment(actor, id, enums.CommentRejected, "reject", reason)
+}
+
+func (cs *CommentService) reviewComment(actor *entities.User, id pkg.ULID, status enums.CommentStatus, action, reason string) (*Comment, error) {
+	comment, err := cs.CommentRepository.GetCommentByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.Status != enums.CommentPending {
+		return nil, ErrCommentNotPending
+	}
+
+	allowed, err := cs.canModeratePost(actor, comment.PostID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowed {
+		return nil, ErrNotPostModerator
+	}
+
+	moderationId, err := pkg.NewULID()
+	if err != nil {
+		return nil, err
+	}
+
+	comment.Status = status
+	err = cs.CommentRepository.ReviewComment(comment, &entities.CommentModeration{
+		ID:              moderationId,
+		CommentID:       comment.ID,
+		PostID:          comment.PostID,
+		CommentAuthorID: comment.UserID,
+		ModeratorID:     actor.ID,
+		ModeratorRole:   actor.Role,
+		Action:          action,
+		Reason:          strings.TrimSpace(reason),
+		Content:         comment.Content,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (cs *CommentService) SetPostCommentApproval(actor *entities.User, postID pkg.ULID, required bool) error {
+	allowed, err := cs.canModeratePost(actor, postID)
+	if err != nil {
+		return err
+	}
+
+	if !allowed {
+		return ErrNotPostModerator
+	}
+
+	return cs.CommentRepository.SetPostCommentApproval(postID, required)
+}
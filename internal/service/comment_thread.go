@@ -0,0 +1,60 @@
+package service
+
+import "github.com/clemilsonazevedo/blog/pkg"
+
+type CommentNode struct {
+	Comment    *Comment
+	ReplyCount int
+	Replies    []*CommentNode
+}
+
+// GetCommentTree groups the comments of a post by parent. Comments come
+// ordered by creation date, so roots and replies keep that order. Replies to
+// a comment that is not shown, like an edit waiting for moderation, are
+// hidden with it.
+func (cs *CommentService) GetCommentTree(postID pkg.ULID) ([]*CommentNode, error) {
+	comments, err := cs.CommentRepository.GetCommentsByPostID(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[pkg.ULID]*CommentNode, len(comments))
+	roots := []*CommentNode{}
+	for _, comment := range comments {
+		node := &CommentNode{Comment: comment}
+		if comment.ParentID == nil {
+			nodes[comment.ID] = node
+			roots = append(roots, node)
+			continue
+		}
+
+		// A reply is always created after its parent, so a parent missing
+		// here is hidden
+		parent, ok := nodes[*comment.ParentID]
+		if !ok {
+			continue
+		}
+
+		nodes[comment.ID] = node
+		parent.Replies = append(parent.Replies, node)
+		parent.ReplyCount++
+	}
+
+	return roots, nil
+}
+
+// FlattenCommentTree lists the nodes depth first, which is the order a
+// thread is read in: every comment followed by its replies.
+func FlattenCommentTree(roots []*CommentNode) []*CommentNode {
+	flat := []*CommentNode{}
+	var walk func(nodes []*CommentNode)
+	walk = func(nodes []*CommentNode) {
+		for _, node := range nodes {
+			flat = append(flat, node)
+			walk(node.Replies)
+		}
+	}
+	walk(roots)
+
+	return flat
+}
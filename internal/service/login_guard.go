@@ -0,0 +1,354 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrLoginThrottled     = errors.New("too many failed login attempts")
+	ErrChallengeExhausted = errors.New("too many codes tried for this two factor challenge")
+)
+
+// LoginGuardConfig sets how failed logins are slowed down. After FreeAttempts
+// failures each new failure blocks the account (or the IP) for BaseDelay,
+// doubled on every failure up to MaxDelay. Reaching MaxAccountFailures or
+// MaxIPFailures locks out for LockoutDuration. Failures are forgotten Window
+// after the last one. A two factor challenge accepts MaxChallengeAttempts
+// codes, then the password must be typed again.
+type LoginGuardConfig struct {
+	FreeAttempts       int
+	BaseDelay          time.Duration
+	MaxDelay           time.Duration
+	MaxAccountFailures int
+	MaxIPFailures      int
+	LockoutDuration    time.Duration
+	Window             time.Duration
+
+	MaxChallengeAttempts int
+
+	// Now is the clock of the guard, time.Now when nil. Tests replace it to
+	// move the time without waiting.
+	Now func() time.Time
+}
+
+// LoginLockout is returned by RecordFailure when a failure locked an account
+// or an IP, it is written to the audit log.
+type LoginLockout struct {
+	// Kind is "account" or "ip"
+	Kind     string
+	Key      string
+	Failures int
+	Until    time.Time
+}
+
+type loginAttempts struct {
+	failures     int
+	lastFailure  time.Time
+	blockedUntil time.Time
+
+	// pending are the attempts allowed by Check whose password is still
+	// being verified, they count as failures until they are recorded.
+	pending   int
+	pendingAt time.Time
+}
+
+type challengeAttempts struct {
+	attempts  int
+	spent     bool
+	expiresAt time.Time
+}
+
+// pendingTimeout forgets the pending attempts of a request that never
+// recorded its result.
+const pendingTimeout = time.Minute
+
+// LoginGuard tracks the failed logins per account and per IP in memory. The
+// account is identified by the email typed, whether it exists or not, so the
+// answers never reveal which emails are registered.
+type LoginGuard struct {
+	config LoginGuardConfig
+
+	mu         sync.Mutex
+	attempts   map[string]*loginAttempts
+	challenges map[string]*challengeAttempts
+}
+
+func NewLoginGuard(config LoginGuardConfig) *LoginGuard {
+	if config.Now == nil {
+		config.Now = time.Now
+	}
+
+	return &LoginGuard{
+		config:     config,
+		attempts:   map[string]*loginAttempts{},
+		challenges: map[string]*challengeAttempts{},
+	}
+}
+
+// Check returns ErrLoginThrottled and how long to wait when the account or
+// the IP is blocked. It is called before the password is verified and
+// reserves the attempt: concurrent requests cannot pass Check together once
+// the free attempts are used, they would all be verified before the first
+// failure is counted. Every allowed attempt must end with RecordFailure,
+// RecordSuccess or Release.
+func (lg *LoginGuard) Check(email string, ip string) (time.Duration, error) {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+
+	now := lg.config.Now()
+	lg.prune(now)
+
+	targets := []struct {
+		key string
+		max int
+	}{
+		{accountKey(email), lg.config.MaxAccountFailures},
+		{ipKey(ip), lg.config.MaxIPFailures},
+	}
+
+	var wait time.Duration
+	for _, target := range targets {
+		a := lg.current(target.key, now)
+		if a == nil {
+			continue
+		}
+		if a.blockedUntil.After(now) {
+			wait = max(wait, a.blockedUntil.Sub(now))
+			continue
+		}
+
+		pending := lg.pending(a, now)
+		if pending == 0 {
+			continue
+		}
+		attempts := a.failures + pending
+		if attempts >= lg.config.FreeAttempts || (target.max > 0 && attempts >= target.max) {
+			wait = max(wait, lg.pendingWait())
+		}
+	}
+
+	if wait > 0 {
+		return wait, ErrLoginThrottled
+	}
+
+	for _, target := range targets {
+		a := lg.current(target.key, now)
+		if a == nil {
+			a = &loginAttempts{}
+			lg.attempts[target.key] = a
+		}
+		a.pending = lg.pending(a, now) + 1
+		a.pendingAt = now
+	}
+	return 0, nil
+}
+
+// RecordFailure counts a failed login for the account and the IP, it returns
+// the lockouts started by this failure.
+func (lg *LoginGuard) RecordFailure(email string, ip string) []LoginLockout {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+
+	now := lg.config.Now()
+	lg.prune(now)
+
+	var lockouts []LoginLockout
+	targets := []struct {
+		kind  string
+		key   string
+		value string
+		max   int
+	}{
+		{"account", accountKey(email), normalizeEmail(email), lg.config.MaxAccountFailures},
+		{"ip", ipKey(ip), ip, lg.config.MaxIPFailures},
+	}
+	for _, target := range targets {
+		a := lg.current(target.key, now)
+		if a == nil {
+			a = &loginAttempts{}
+			lg.attempts[target.key] = a
+		}
+		lg.release(a, now)
+		a.failures++
+		a.lastFailure = now
+
+		if target.max > 0 && a.failures >= target.max {
+			// Concurrent failures may arrive while the lockout starts
+			wasLocked := a.blockedUntil.After(now)
+			a.blockedUntil = now.Add(lg.config.LockoutDuration)
+			if !wasLocked {
+				lockouts = append(lockouts, LoginLockout{
+					Kind:     target.kind,
+					Key:      target.value,
+					Failures: a.failures,
+					Until:    a.blockedUntil,
+				})
+			}
+			continue
+		}
+
+		if delay := lg.backoff(a.failures); delay > 0 {
+			a.blockedUntil = now.Add(delay)
+		}
+	}
+
+	return lockouts
+}
+
+// RecordSuccess forgets the failures of the account. The IP keeps its
+// failures, a valid account must not reset the counter of an attacker.
+func (lg *LoginGuard) RecordSuccess(email string, ip string) {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+
+	delete(lg.attempts, accountKey(email))
+	if a := lg.current(ipKey(ip), lg.config.Now()); a != nil {
+		lg.release(a, lg.config.Now())
+	}
+}
+
+// Release ends an attempt allowed by Check that was neither a failure nor a
+// success, like an error of the database.
+func (lg *LoginGuard) Release(email string, ip string) {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+
+	now := lg.config.Now()
+	for _, key := range []string{accountKey(email), ipKey(ip)} {
+		if a := lg.current(key, now); a != nil {
+			lg.release(a, now)
+		}
+	}
+}
+
+// UseChallenge counts a code tried for a two factor challenge, before it is
+// verified. It returns ErrChallengeExhausted once the challenge tried
+// MaxChallengeAttempts codes or was already used to login.
+func (lg *LoginGuard) UseChallenge(id string, expiresAt time.Time) error {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+
+	now := lg.config.Now()
+	lg.pruneChallenges(now)
+
+	c, ok := lg.challenges[id]
+	if !ok {
+		c = &challengeAttempts{expiresAt: expiresAt}
+		lg.challenges[id] = c
+	}
+
+	if c.spent || (lg.config.MaxChallengeAttempts > 0 && c.attempts >= lg.config.MaxChallengeAttempts) {
+		return ErrChallengeExhausted
+	}
+	c.attempts++
+	return nil
+}
+
+// SpendChallenge ends a challenge after a successful login, it cannot be
+// used again.
+func (lg *LoginGuard) SpendChallenge(id string) {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+
+	if c, ok := lg.challenges[id]; ok {
+		c.spent = true
+	}
+}
+
+// pruneChallenges drops the expired challenges once the map grows.
+func (lg *LoginGuard) pruneChallenges(now time.Time) {
+	if len(lg.challenges) < 10000 {
+		return
+	}
+	for id, c := range lg.challenges {
+		if !c.expiresAt.After(now) {
+			delete(lg.challenges, id)
+		}
+	}
+}
+
+// pending returns the pending attempts of a, forgetting them after
+// pendingTimeout.
+func (lg *LoginGuard) pending(a *loginAttempts, now time.Time) int {
+	if a.pending > 0 && now.Sub(a.pendingAt) > pendingTimeout {
+		a.pending = 0
+	}
+	return a.pending
+}
+
+func (lg *LoginGuard) release(a *loginAttempts, now time.Time) {
+	if lg.pending(a, now) > 0 {
+		a.pending--
+	}
+}
+
+// pendingWait is the wait given to an attempt refused because another one
+// is being verified.
+func (lg *LoginGuard) pendingWait() time.Duration {
+	if lg.config.BaseDelay > 0 {
+		return lg.config.BaseDelay
+	}
+	return time.Second
+}
+
+// backoff is the delay after the nth failure.
+func (lg *LoginGuard) backoff(failures int) time.Duration {
+	extra := failures - lg.config.FreeAttempts
+	if extra <= 0 || lg.config.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := lg.config.BaseDelay
+	for i := 1; i < extra && delay < lg.config.MaxDelay; i++ {
+		delay *= 2
+	}
+	if lg.config.MaxDelay > 0 && delay > lg.config.MaxDelay {
+		delay = lg.config.MaxDelay
+	}
+	return delay
+}
+
+// current returns the attempts of key, nil once they expired.
+func (lg *LoginGuard) current(key string, now time.Time) *loginAttempts {
+	a, ok := lg.attempts[key]
+	if !ok {
+		return nil
+	}
+	if lg.expired(a, now) {
+		delete(lg.attempts, key)
+		return nil
+	}
+	return a
+}
+
+func (lg *LoginGuard) expired(a *loginAttempts, now time.Time) bool {
+	return !a.blockedUntil.After(now) && now.Sub(a.lastFailure) > lg.config.Window && lg.pending(a, now) == 0
+}
+
+// prune drops the expired entries once the map grows, so random emails or
+// IPs cannot fill the memory.
+func (lg *LoginGuard) prune(now time.Time) {
+	if len(lg.attempts) < 10000 {
+		return
+	}
+	for key, a := range lg.attempts {
+		if lg.expired(a, now) {
+			delete(lg.attempts, key)
+		}
+	}
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func accountKey(email string) string {
+	return "account:" + normalizeEmail(email)
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newTestGuard(config LoginGuardConfig) (*LoginGuard, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+	config.Now = clock.Now
+	return NewLoginGuard(config), clock
+}
+
+// fail makes a login attempt that passes Check and fails.
+func fail(t *testing.T, guard *LoginGuard, email string, ip string) []LoginLockout {
+	t.Helper()
+	if wait, err := guard.Check(email, ip); err != nil {
+		t.Fatalf("Check(%q, %q) = %v, %v; want allowed", email, ip, wait, err)
+	}
+	return guard.RecordFailure(email, ip)
+}
+
+func TestLoginGuardBackoff(t *testing.T) {
+	guard, clock := newTestGuard(LoginGuardConfig{
+		FreeAttempts: 3,
+		BaseDelay:    time.Second,
+		MaxDelay:     4 * time.Second,
+		Window:       time.Hour,
+	})
+
+	want := []time.Duration{0, 0, 0, time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second}
+	for i, delay := range want {
+		fail(t, guard, "reader@blog.io", "10.0.0.1")
+
+		wait, err := guard.Check("reader@blog.io", "10.0.0.1")
+		if delay == 0 {
+			if err != nil {
+				t.Fatalf("failure %d: blocked for %v, want no delay", i+1, wait)
+			}
+			guard.Release("reader@blog.io", "10.0.0.1")
+			continue
+		}
+
+		if !errors.Is(err, ErrLoginThrottled) || wait != delay {
+			t.Fatalf("failure %d: Check = %v, %v; want %v", i+1, wait, err, delay)
+		}
+		clock.Advance(wait)
+	}
+}
+
+func TestLoginGuardAccountLockout(t *testing.T) {
+	guard, clock := newTestGuard(LoginGuardConfig{
+		FreeAttempts:       10,
+		MaxAccountFailures: 5,
+		LockoutDuration:    time.Minute,
+		Window:             time.Hour,
+	})
+
+	for i := 1; i < 5; i++ {
+		if lockouts := fail(t, guard, "Reader@Blog.io", "10.0.0.1"); len(lockouts) != 0 {
+			t.Fatalf("failure %d locked out: %+v", i, lockouts)
+		}
+	}
+
+	lockouts := fail(t, guard, "reader@blog.io", "10.0.0.2")
+	if len(lockouts) != 1 || lockouts[0].Kind != "account" || lockouts[0].Key != "reader@blog.io" || lockouts[0].Failures != 5 {
+		t.Fatalf("lockouts = %+v, want the account after 5 failures", lockouts)
+	}
+
+	// Another IP does not help, the account is locked
+	if wait, err := guard.Check("reader@blog.io", "10.0.0.3"); !errors.Is(err, ErrLoginThrottled) || wait != time.Minute {
+		t.Fatalf("Check = %v, %v; want locked for 1m", wait, err)
+	}
+
+	clock.Advance(time.Minute)
+	if _, err := guard.Check("reader@blog.io", "10.0.0.3"); err != nil {
+		t.Fatalf("Check after the lockout: %v", err)
+	}
+}
+
+func TestLoginGuardIPLockout(t *testing.T) {
+	guard, _ := newTestGuard(LoginGuardConfig{
+		FreeAttempts:    10,
+		MaxIPFailures:   3,
+		LockoutDuration: time.Minute,
+		Window:          time.Hour,
+	})
+
+	fail(t, guard, "a@blog.io", "10.0.0.1")
+	fail(t, guard, "b@blog.io", "10.0.0.1")
+	lockouts := fail(t, guard, "c@blog.io", "10.0.0.1")
+	if len(lockouts) != 1 || lockouts[0].Kind != "ip" || lockouts[0].Key != "10.0.0.1" {
+		t.Fatalf("lockouts = %+v, want the IP", lockouts)
+	}
+
+	if _, err := guard.Check("d@blog.io", "10.0.0.1"); !errors.Is(err, ErrLoginThrottled) {
+		t.Fatalf("Check from the locked IP: %v, want throttled", err)
+	}
+	if _, err := guard.Check("d@blog.io", "10.0.0.2"); err != nil {
+		t.Fatalf("Check from another IP: %v", err)
+	}
+}
+
+func TestLoginGuardWindowExpiry(t *testing.T) {
+	guard, clock := newTestGuard(LoginGuardConfig{
+		FreeAttempts: 2,
+		BaseDelay:    time.Second,
+		MaxDelay:     time.Minute,
+		Window:       15 * time.Minute,
+	})
+
+	fail(t, guard, "reader@blog.io", "10.0.0.1")
+	fail(t, guard, "reader@blog.io", "10.0.0.1")
+
+	clock.Advance(15*time.Minute + time.Second)
+
+	// The failures are forgotten, the third one is free again
+	fail(t, guard, "reader@blog.io", "10.0.0.1")
+	if _, err := guard.Check("reader@blog.io", "10.0.0.1"); err != nil {
+		t.Fatalf("Check after the window: %v", err)
+	}
+}
+
+func TestLoginGuardSuccessResetsAccountOnly(t *testing.T) {
+	guard, clock := newTestGuard(LoginGuardConfig{
+		FreeAttempts:       10,
+		MaxAccountFailures: 3,
+		MaxIPFailures:      4,
+		LockoutDuration:    time.Minute,
+		Window:             time.Hour,
+	})
+
+	fail(t, guard, "reader@blog.io", "10.0.0.1")
+	fail(t, guard, "reader@blog.io", "10.0.0.1")
+	if _, err := guard.Check("reader@blog.io", "10.0.0.1"); err != nil {
+		t.Fatal(err)
+	}
+	guard.RecordSuccess("reader@blog.io", "10.0.0.1")
+
+	// The account starts again from zero: two failures do not lock it
+	clock.Advance(time.Second)
+	if lockouts := fail(t, guard, "reader@blog.io", "10.0.0.2"); len(lockouts) != 0 {
+		t.Fatalf("lockouts = %+v after the success", lockouts)
+	}
+
+	// The IP kept its two failures, two more lock it
+	fail(t, guard, "other@blog.io", "10.0.0.1")
+	lockouts := fail(t, guard, "another@blog.io", "10.0.0.1")
+	if len(lockouts) != 1 || lockouts[0].Kind != "ip" {
+		t.Fatalf("lockouts = %+v, want the IP", lockouts)
+	}
+}
+
+func TestLoginGuardConcurrentAttempts(t *testing.T) {
+	guard, clock := newTestGuard(LoginGuardConfig{
+		FreeAttempts: 2,
+		BaseDelay:    time.Second,
+		MaxDelay:     time.Minute,
+		Window:       time.Hour,
+	})
+
+	// Two attempts are being verified, the free attempts are used
+	for i := 0; i < 2; i++ {
+		if _, err := guard.Check("reader@blog.io", "10.0.0.1"); err != nil {
+			t.Fatalf("attempt %d: %v", i+1, err)
+		}
+	}
+	if _, err := guard.Check("reader@blog.io", "10.0.0.2"); !errors.Is(err, ErrLoginThrottled) {
+		t.Fatalf("third concurrent attempt: %v, want throttled", err)
+	}
+
+	guard.Release("reader@blog.io", "10.0.0.1")
+	if _, err := guard.Check("reader@blog.io", "10.0.0.2"); err != nil {
+		t.Fatalf("attempt after a release: %v", err)
+	}
+
+	// A request that never records its result does not block forever
+	clock.Advance(pendingTimeout + time.Second)
+	for i := 0; i < 2; i++ {
+		if _, err := guard.Check("reader@blog.io", "10.0.0.3"); err != nil {
+			t.Fatalf("attempt %d after the timeout: %v", i+1, err)
+		}
+	}
+}
+
+func TestLoginGuardChallengeAttempts(t *testing.T) {
+	guard, clock := newTestGuard(LoginGuardConfig{MaxChallengeAttempts: 5})
+	expires := clock.Now().Add(5 * time.Minute)
+
+	for i := 0; i < 5; i++ {
+		if err := guard.UseChallenge("challenge-1", expires); err != nil {
+			t.Fatalf("code %d: %v", i+1, err)
+		}
+	}
+	if err := guard.UseChallenge("challenge-1", expires); !errors.Is(err, ErrChallengeExhausted) {
+		t.Fatalf("sixth code: %v, want exhausted", err)
+	}
+
+	// A challenge used to login cannot be used again
+	if err := guard.UseChallenge("challenge-2", expires); err != nil {
+		t.Fatal(err)
+	}
+	guard.SpendChallenge("challenge-2")
+	if err := guard.UseChallenge("challenge-2", expires); !errors.Is(err, ErrChallengeExhausted) {
+		t.Fatalf("spent challenge: %v, want exhausted", err)
+	}
+}
+
+func TestLoginGuardTwoFactorFailuresSurvivePassword(t *testing.T) {
+	guard, clock := newTestGuard(LoginGuardConfig{
+		FreeAttempts:       3,
+		BaseDelay:          time.Second,
+		MaxDelay:           time.Minute,
+		MaxAccountFailures: 10,
+		LockoutDuration:    15 * time.Minute,
+		Window:             time.Hour,
+	})
+
+	// check waits out the backoff, from a new IP each time like an attacker
+	// spreading its attempts
+	attempt := 0
+	check := func() (string, error) {
+		attempt++
+		ip := fmt.Sprintf("10.0.0.%d", attempt)
+		wait, err := guard.Check("reader@blog.io", ip)
+		if errors.Is(err, ErrLoginThrottled) && wait < time.Minute {
+			clock.Advance(wait)
+			wait, err = guard.Check("reader@blog.io", ip)
+		}
+		return ip, err
+	}
+
+	for round := 0; round < 2; round++ {
+		// The password is right, the account has two factor: the attempt
+		// is released, not a success
+		ip, err := check()
+		if err != nil {
+			t.Fatalf("round %d: password step: %v", round+1, err)
+		}
+		guard.Release("reader@blog.io", ip)
+
+		for code := 0; code < 5; code++ {
+			ip, err := check()
+			if err != nil {
+				t.Fatalf("round %d code %d: %v", round+1, code+1, err)
+			}
+			lockouts := guard.RecordFailure("reader@blog.io", ip)
+			if round == 1 && code == 4 {
+				if len(lockouts) != 1 || lockouts[0].Kind != "account" || lockouts[0].Failures != 10 {
+					t.Fatalf("lockouts = %+v, want the account after 10 wrong codes", lockouts)
+				}
+			} else if len(lockouts) != 0 {
+				t.Fatalf("round %d code %d: early lockouts %+v", round+1, code+1, lockouts)
+			}
+		}
+	}
+
+	if _, err := check(); !errors.Is(err, ErrLoginThrottled) {
+		t.Fatalf("password step after the lockout: %v, want throttled", err)
+	}
+}
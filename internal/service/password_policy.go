@@ -0,0 +1,70 @@
+package service
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+var (
+	ErrPasswordTooShort  = errors.New("password is too short")
+	ErrPasswordTooLong   = errors.New("password is too long")
+	ErrPasswordTooCommon = errors.New("password is too common")
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsFile string
+
+var commonPasswords = sync.OnceValue(func() map[string]struct{} {
+	passwords := make(map[string]struct{})
+	scanner := bufio.NewScanner(strings.NewReader(commonPasswordsFile))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords[strings.ToLower(line)] = struct{}{}
+	}
+	return passwords
+})
+
+// PasswordPolicy is checked every time a user chooses a password. MinLength
+// counts characters, MaxLength counts bytes since bcrypt reads 72 at most.
+type PasswordPolicy struct {
+	MinLength int
+	MaxLength int
+}
+
+// Check returns ErrPasswordTooShort, ErrPasswordTooLong or
+// ErrPasswordTooCommon. The words of the account (email, username) are
+// refused too, an attacker tries them first.
+func (p PasswordPolicy) Check(password string, accountWords ...string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return ErrPasswordTooShort
+	}
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		return ErrPasswordTooLong
+	}
+
+	lower := strings.ToLower(password)
+	if _, ok := commonPasswords()[lower]; ok {
+		return ErrPasswordTooCommon
+	}
+
+	for _, word := range accountWords {
+		word = strings.ToLower(strings.TrimSpace(word))
+		local, _, _ := strings.Cut(word, "@")
+		if word != "" && (lower == word || lower == local) {
+			return ErrPasswordTooCommon
+		}
+	}
+
+	return nil
+}
+
+func (us *UserService) PasswordPolicy() PasswordPolicy {
+	return us.config.PasswordPolicy
+}
@@ -1,25 +1,35 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/clemilsonazevedo/blog/internal/cache"
 	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
 	"github.com/clemilsonazevedo/blog/internal/repository"
 	"github.com/clemilsonazevedo/blog/pkg"
 	"github.com/gosimple/slug"
 )
 
+var (
+	ErrInvalidPostStatus = errors.New("invalid post status")
+	ErrInvalidSchedule   = errors.New("scheduled_for must be a future date")
+)
+
 type Post = entities.Post
 type PostRepository = repository.PostRepository
 type PostService struct {
 	PostRepository *PostRepository
+	TagRepository  *TagRepository
 	cache          *cache.PostCache
 }
 
-func NewPostService(PostRepository *PostRepository, postCache *cache.PostCache) *PostService {
+func NewPostService(PostRepository *PostRepository, TagRepository *TagRepository, postCache *cache.PostCache) *PostService {
 	return &PostService{
 		PostRepository: PostRepository,
+		TagRepository:  TagRepository,
 		cache:          postCache,
 	}
 }
@@ -34,16 +44,22 @@ func (ps *PostService) CreatePost(post *Post) error {
 }
 
 func (ps *PostService) UpdatePost(post *Post) error {
-	if err := ps.PostRepository.UpdatePost(post); err != nil {
+	previous, err := ps.PostRepository.FindPostByID(post.ID)
+	if err != nil {
 		return err
 	}
 
+	if err := ps.PostRepository.UpdatePost(post, previous); err != nil {
+		return err
+	}
+
+	ps.cache.InvalidatePost(post.ID, previous.Slug)
 	ps.cache.InvalidateLists()
 	return nil
 }
 
 func (ps *PostService) DeletePost(id pkg.ULID) error {
-	post, _ := ps.PostRepository.GetPostByID(id)
+	post, _ := ps.PostRepository.FindPostByID(id)
 	slug := ""
 	if post != nil {
 		slug = post.Slug
@@ -58,6 +74,10 @@ func (ps *PostService) DeletePost(id pkg.ULID) error {
 	return nil
 }
 
+func (ps *PostService) FindPostByID(id pkg.ULID) (*Post, error) {
+	return ps.PostRepository.FindPostByID(id)
+}
+
 func (ps *PostService) GetPostByID(id pkg.ULID) (*Post, error) {
 	post, err := ps.PostRepository.GetPostByID(id)
 	if err != nil {
@@ -67,6 +87,29 @@ func (ps *PostService) GetPostByID(id pkg.ULID) (*Post, error) {
 	return post, nil
 }
 
+func (ps *PostService) GetPostBySlug(slug string) (*Post, error) {
+	post, found := ps.cache.GetBySlug(slug)
+	if !found {
+		var err error
+		post, err = ps.PostRepository.FindPostBySlug(slug)
+		if err != nil {
+			return nil, err
+		}
+
+		ps.cache.SetBySlug(slug, post)
+	}
+
+	if err := ps.PostRepository.IncrementViews(post.ID); err != nil {
+		return nil, err
+	}
+
+	return post, nil
+}
+
+func (ps *PostService) GetCurrentSlug(oldSlug string) (string, error) {
+	return ps.PostRepository.FindCurrentSlug(oldSlug)
+}
+
 func (ps *PostService) GetAllPosts() ([]*Post, error) {
 	if posts, found := ps.cache.GetAll(); found {
 		return posts, nil
@@ -81,21 +124,111 @@ func (ps *PostService) GetAllPosts() ([]*Post, error) {
 	return posts, nil
 }
 
-func (ps *PostService) GetPaginatedPosts(page, limit int) ([]entities.Post, int64, error) {
-	if result, found := ps.cache.GetPaginated(page, limit); found {
+func (ps *PostService) GetPaginatedPosts(page, limit int, tag string) ([]entities.Post, int64, error) {
+	tagSlug := ""
+	if tag != "" {
+		tagSlug = slug.Make(tag)
+	}
+
+	if result, found := ps.cache.GetPaginated(page, limit, tagSlug); found {
 		return result.Posts, result.Total, nil
 	}
 
 	offset := (page - 1) * limit
-	posts, total, err := ps.PostRepository.FindAllPaginated(limit, offset)
+	posts, total, err := ps.PostRepository.FindAllPaginated(limit, offset, tagSlug)
 	if err != nil {
 		return nil, 0, err
 	}
 
-	ps.cache.SetPaginated(page, limit, posts, total)
+	ps.cache.SetPaginated(page, limit, tagSlug, posts, total)
 	return posts, total, nil
 }
 
+func (ps *PostService) GetDraftsByAuthor(authorId pkg.ULID) ([]entities.Post, error) {
+	return ps.PostRepository.FindPostsByAuthorAndStatus(authorId, []enums.PostStatus{enums.Draft, enums.Scheduled})
+}
+
+// SetStatus only fills the lifecycle fields of the post, callers still need
+// to persist it with CreatePost or ChangeStatus.
+func (ps *PostService) SetStatus(post *Post, status enums.PostStatus, scheduledFor *time.Time) error {
+	if status == "" {
+		status = enums.Draft
+	}
+
+	if !status.IsValid() {
+		return ErrInvalidPostStatus
+	}
+
+	if status == enums.Published && scheduledFor != nil && scheduledFor.After(time.Now()) {
+		status = enums.Scheduled
+	}
+
+	post.Status = status
+	post.ScheduledFor = nil
+
+	switch status {
+	case enums.Published:
+		if post.PublishedAt == nil {
+			now := time.Now().UTC()
+			post.PublishedAt = &now
+		}
+	case enums.Scheduled:
+		if scheduledFor == nil || !scheduledFor.After(time.Now()) {
+			return ErrInvalidSchedule
+		}
+		at := scheduledFor.UTC()
+		post.ScheduledFor = &at
+	case enums.Draft:
+		post.PublishedAt = nil
+	}
+
+	return nil
+}
+
+func (ps *PostService) ChangeStatus(post *Post, status enums.PostStatus, scheduledFor *time.Time) error {
+	if err := ps.SetStatus(post, status, scheduledFor); err != nil {
+		return err
+	}
+
+	if err := ps.PostRepository.UpdateStatus(post); err != nil {
+		return err
+	}
+
+	ps.cache.InvalidatePost(post.ID, post.Slug)
+	ps.cache.InvalidateLists()
+	return nil
+}
+
+func (ps *PostService) PublishDuePosts() (int64, error) {
+	published, err := ps.PostRepository.PublishScheduled(time.Now().UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	if published > 0 {
+		ps.cache.InvalidateLists()
+	}
+
+	return published, nil
+}
+
+func (ps *PostService) SearchPosts(query string, page, limit int) ([]repository.PostSearchResult, int64, error) {
+	offset := (page - 1) * limit
+	return ps.PostRepository.SearchPosts(query, limit, offset)
+}
+
+func (ps *PostService) ResolveTags(names []string) ([]entities.Tag, error) {
+	return ps.TagRepository.FindOrCreateByNames(pkg.NormalizeTags(names))
+}
+
+func (ps *PostService) RegenerateSlug(post *Post, title string) (string, error) {
+	if slug.Make(title) == slug.Make(post.Title) {
+		return post.Slug, nil
+	}
+
+	return ps.GenerateUniqueSlug(title)
+}
+
 func (ps *PostService) GenerateUniqueSlug(title string) (string, error) {
 	base := slug.Make(title)
 	slug := base
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+var ErrInvalidReaction = errors.New("reaction must be like or dislike")
+
+func (ps *PostService) React(userId, postId pkg.ULID, reaction enums.Reaction) (*Post, error) {
+	if !reaction.IsValid() {
+		return nil, ErrInvalidReaction
+	}
+
+	post, err := ps.PostRepository.SetReaction(userId, postId, reaction)
+	if err != nil {
+		return nil, err
+	}
+
+	ps.cache.InvalidatePost(post.ID, post.Slug)
+	ps.cache.InvalidateLists()
+	return post, nil
+}
+
+func (ps *PostService) RemoveReaction(userId, postId pkg.ULID) (*Post, error) {
+	post, err := ps.PostRepository.RemoveReaction(userId, postId)
+	if err != nil {
+		return nil, err
+	}
+
+	ps.cache.InvalidatePost(post.ID, post.Slug)
+	ps.cache.InvalidateLists()
+	return post, nil
+}
+
+func (ps *PostService) GetUserReaction(userId, postId pkg.ULID) (enums.Reaction, error) {
+	return ps.PostRepository.FindReaction(userId, postId)
+}
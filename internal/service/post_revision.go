@@ -0,0 +1,67 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+func (ps *PostService) GetRevisions(postId pkg.ULID) ([]entities.PostRevision, error) {
+	if _, err := ps.PostRepository.FindPostByID(postId); err != nil {
+		return nil, err
+	}
+
+	return ps.PostRepository.GetRevisionsByPostID(postId)
+}
+
+func (ps *PostService) DiffRevision(postId pkg.ULID, revision int) (string, error) {
+	post, err := ps.PostRepository.FindPostByID(postId)
+	if err != nil {
+		return "", err
+	}
+
+	postRevision, err := ps.PostRepository.GetRevision(postId, revision)
+	if err != nil {
+		return "", err
+	}
+
+	return pkg.UnifiedDiff(
+		fmt.Sprintf("revision %d", postRevision.Revision),
+		"current",
+		postRevision.Content,
+		post.Content,
+	), nil
+}
+
+// RestoreRevision goes through UpdatePost, so the version being replaced is
+// kept as a new revision and the restore itself can be rolled back.
+func (ps *PostService) RestoreRevision(postId pkg.ULID, revision int) (*Post, error) {
+	current, err := ps.PostRepository.FindPostByID(postId)
+	if err != nil {
+		return nil, err
+	}
+
+	postRevision, err := ps.PostRepository.GetRevision(postId, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	slug, err := ps.RegenerateSlug(current, postRevision.Title)
+	if err != nil {
+		return nil, err
+	}
+
+	restored := *current
+	restored.Title = postRevision.Title
+	restored.Content = postRevision.Content
+	restored.Slug = slug
+	restored.Tags = nil
+
+	if err := ps.UpdatePost(&restored); err != nil {
+		return nil, err
+	}
+
+	restored.Tags = current.Tags
+	return &restored, nil
+}
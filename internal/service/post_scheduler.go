@@ -0,0 +1,27 @@
+package service
+
+import (
+	"log"
+	"time"
+)
+
+func (ps *PostService) StartScheduler(interval time.Duration) {
+	go ps.runScheduler(interval)
+}
+
+func (ps *PostService) runScheduler(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		published, err := ps.PublishDuePosts()
+		if err != nil {
+			log.Printf("post scheduler: %v", err)
+			continue
+		}
+
+		if published > 0 {
+			log.Printf("post scheduler: %d scheduled posts published", published)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/repository"
+)
+
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// RateLimitPolicy is a token bucket of Limit requests refilled in Period: a
+// client can burst Limit requests, then gets one every Period/Limit. A zero
+// Limit disables the policy.
+type RateLimitPolicy struct {
+	// Name separates the buckets of the policies, a client has one bucket
+	// per policy.
+	Name   string
+	Limit  int
+	Period time.Duration
+}
+
+func (p RateLimitPolicy) Enabled() bool {
+	return p.Limit > 0 && p.Period > 0
+}
+
+// ParseRateLimitPolicy reads a policy written as "limit/period", like
+// "10/1m". "0" or "off" disable it.
+func ParseRateLimitPolicy(name string, spec string) (RateLimitPolicy, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "0" || spec == "off" {
+		return RateLimitPolicy{Name: name}, nil
+	}
+
+	limitStr, periodStr, found := strings.Cut(spec, "/")
+	if !found {
+		return RateLimitPolicy{}, fmt.Errorf("rate limit %s: %q is not limit/period", name, spec)
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 0 {
+		return RateLimitPolicy{}, fmt.Errorf("rate limit %s: invalid limit %q", name, limitStr)
+	}
+
+	period, err := time.ParseDuration(periodStr)
+	if err != nil || period <= 0 {
+		return RateLimitPolicy{}, fmt.Errorf("rate limit %s: invalid period %q", name, periodStr)
+	}
+
+	return RateLimitPolicy{Name: name, Limit: limit, Period: period}, nil
+}
+
+// RateLimitResult is the state of a bucket after a request, it fills the
+// RateLimit-* headers. Reset is how long until the bucket is full again,
+// RetryAfter how long until the next token when the request was refused.
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// RateLimitStore keeps the buckets. MemoryRateLimitStore is enough for one
+// instance of the API, PostgresRateLimitStore shares the buckets between
+// instances.
+type RateLimitStore interface {
+	Take(key string, policy RateLimitPolicy, now time.Time) (RateLimitResult, error)
+}
+
+// rateLimitSweepInterval is how often the stores delete the full buckets.
+const rateLimitSweepInterval = time.Minute
+
+// takeToken refills a bucket of tokens last refilled at refilledAt and takes
+// one token when there is one. It returns the tokens left and when the
+// bucket will be full.
+func takeToken(tokens float64, refilledAt time.Time, policy RateLimitPolicy, now time.Time) (float64, time.Time, RateLimitResult) {
+	capacity := float64(policy.Limit)
+	rate := capacity / policy.Period.Seconds()
+
+	elapsed := max(now.Sub(refilledAt).Seconds(), 0)
+	tokens = min(capacity, tokens+elapsed*rate)
+
+	result := RateLimitResult{Limit: policy.Limit}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsDuration((1 - tokens) / rate)
+	}
+
+	result.Remaining = int(math.Floor(tokens))
+	result.Reset = secondsDuration((capacity - tokens) / rate)
+	return tokens, now.Add(result.Reset), result
+}
+
+func secondsDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
+
+type memoryBucket struct {
+	tokens     float64
+	refilledAt time.Time
+	fullAt     time.Time
+}
+
+// MemoryRateLimitStore keeps the buckets in the memory of this instance.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: map[string]*memoryBucket{},
+	}
+}
+
+func (ms *MemoryRateLimitStore) Take(key string, policy RateLimitPolicy, now time.Time) (RateLimitResult, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	ms.sweep(now)
+
+	bucket, ok := ms.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{}
+		ms.buckets[key] = bucket
+	}
+
+	var result RateLimitResult
+	bucket.tokens, bucket.fullAt, result = takeToken(bucket.tokens, bucket.refilledAt, policy, now)
+	bucket.refilledAt = now
+	return result, nil
+}
+
+// sweep drops the full buckets, so random IPs cannot fill the memory.
+func (ms *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(ms.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	ms.lastSweep = now
+
+	for key, bucket := range ms.buckets {
+		if bucket.fullAt.Before(now) {
+			delete(ms.buckets, key)
+		}
+	}
+}
+
+// PostgresRateLimitStore keeps the buckets in the database, for deployments
+// with several instances of the API. Each request locks its bucket row.
+type PostgresRateLimitStore struct {
+	rateLimitRepository *repository.RateLimitRepository
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewPostgresRateLimitStore(rateLimitRepository *repository.RateLimitRepository) *PostgresRateLimitStore {
+	return &PostgresRateLimitStore{
+		rateLimitRepository: rateLimitRepository,
+	}
+}
+
+func (ps *PostgresRateLimitStore) Take(key string, policy RateLimitPolicy, now time.Time) (RateLimitResult, error) {
+	ps.sweep(now)
+
+	var result RateLimitResult
+	err := ps.rateLimitRepository.UpdateBucket(key, func(bucket *entities.RateLimitBucket) {
+		bucket.Tokens, bucket.FullAt, result = takeToken(bucket.Tokens, bucket.RefilledAt, policy, now)
+		bucket.RefilledAt = now
+	})
+	return result, err
+}
+
+func (ps *PostgresRateLimitStore) sweep(now time.Time) {
+	ps.mu.Lock()
+	if now.Sub(ps.lastSweep) < rateLimitSweepInterval {
+		ps.mu.Unlock()
+		return
+	}
+	ps.lastSweep = now
+	ps.mu.Unlock()
+
+	if _, err := ps.rateLimitRepository.DeleteFullBuckets(now); err != nil {
+		log.Printf("rate limit: cannot delete full buckets: %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/http/auth"
+	"github.com/clemilsonazevedo/blog/internal/repository"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reused, session revoked")
+	ErrSessionNotFound     = errors.New("session not found")
+)
+
+type SessionConfig struct {
+	AccessTTL  time.Duration
+	RefreshTTL time.Duration
+}
+
+// SessionMeta identifies where a session is used from.
+type SessionMeta struct {
+	Device    string
+	IP        string
+	UserAgent string
+}
+
+type SessionTokens struct {
+	SessionID      pkg.ULID
+	AccessToken    string
+	AccessExpires  time.Time
+	RefreshToken   string
+	RefreshExpires time.Time
+}
+
+type SessionService struct {
+	sessionRepository *repository.SessionRepository
+	userRepository    *repository.UserRepository
+	config            SessionConfig
+}
+
+func NewSessionService(sessionRepository *repository.SessionRepository, userRepository *repository.UserRepository, config SessionConfig) *SessionService {
+	return &SessionService{
+		sessionRepository: sessionRepository,
+		userRepository:    userRepository,
+		config:            config,
+	}
+}
+
+// StartSession logs the user in: it opens a new token family and issues the
+// first access and refresh tokens.
+func (ss *SessionService) StartSession(user *entities.User, meta SessionMeta) (*SessionTokens, error) {
+	familyId, err := pkg.NewULID()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	session := entities.Session{
+		ID:         familyId,
+		FamilyID:   familyId,
+		UserID:     user.ID,
+		Device:     meta.Device,
+		IP:         meta.IP,
+		UserAgent:  meta.UserAgent,
+		SignedInAt: now,
+		ExpiresAt:  now.Add(ss.config.RefreshTTL),
+	}
+
+	refreshToken, tokenHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+	session.TokenHash = tokenHash
+
+	if err := ss.sessionRepository.CreateSession(&session); err != nil {
+		return nil, err
+	}
+
+	return ss.issueTokens(user, &session, refreshToken)
+}
+
+// Refresh exchanges a refresh token for a new pair. A refresh token can only
+// be used once: presenting a rotated one means it was stolen (or the client
+// is replaying it), so the whole family is revoked. The session of the
+// token is returned with ErrRefreshTokenReused for auditing.
+func (ss *SessionService) Refresh(refreshToken string, meta SessionMeta) (*SessionTokens, *entities.Session, error) {
+	current, err := ss.sessionRepository.FindByTokenHash(auth.HashOpaqueToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidRefreshToken
+		}
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if current.RevokedAt != nil || !current.ExpiresAt.After(now) {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	if current.RotatedAt != nil {
+		return nil, current, ss.revokeReused(current, now)
+	}
+
+	user, err := ss.userRepository.GetUserByID(current.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidRefreshToken
+		}
+		return nil, nil, err
+	}
+
+	nextId, err := pkg.NewULID()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nextToken, tokenHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next := entities.Session{
+		ID:         nextId,
+		FamilyID:   current.FamilyID,
+		UserID:     current.UserID,
+		TokenHash:  tokenHash,
+		Device:     current.Device,
+		IP:         meta.IP,
+		UserAgent:  meta.UserAgent,
+		SignedInAt: current.SignedInAt,
+		ExpiresAt:  now.Add(ss.config.RefreshTTL),
+	}
+
+	rotated, err := ss.sessionRepository.Rotate(current, &next, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !rotated {
+		return nil, current, ss.revokeReused(current, now)
+	}
+
+	tokens, err := ss.issueTokens(user, &next, nextToken)
+	return tokens, &next, err
+}
+
+func (ss *SessionService) revokeReused(session *entities.Session, now time.Time) error {
+	if err := ss.sessionRepository.RevokeFamily(session.FamilyID, now); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+func (ss *SessionService) issueTokens(user *entities.User, session *entities.Session, refreshToken string) (*SessionTokens, error) {
+	accessToken, exp, err := auth.GenerateJWT(*user, session.FamilyID, ss.config.AccessTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionTokens{
+		SessionID:      session.FamilyID,
+		AccessToken:    accessToken,
+		AccessExpires:  time.Unix(exp, 0),
+		RefreshToken:   refreshToken,
+		RefreshExpires: session.ExpiresAt,
+	}, nil
+}
+
+func (ss *SessionService) IsActive(userId pkg.ULID, sessionId pkg.ULID) (bool, error) {
+	return ss.sessionRepository.IsActive(userId, sessionId, time.Now())
+}
+
+func (ss *SessionService) GetActiveSessions(userId pkg.ULID) ([]entities.Session, error) {
+	return ss.sessionRepository.FindActiveByUser(userId, time.Now())
+}
+
+func (ss *SessionService) EndSession(sessionId pkg.ULID) error {
+	return ss.sessionRepository.RevokeFamily(sessionId, time.Now())
+}
+
+// RevokeSession ends a session of the user, other users' sessions are
+// reported as not found.
+func (ss *SessionService) RevokeSession(userId pkg.ULID, sessionId pkg.ULID) error {
+	revoked, err := ss.sessionRepository.RevokeUserFamily(userId, sessionId, time.Now())
+	if err != nil {
+		return err
+	}
+	if !revoked {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+func (ss *SessionService) RevokeAllSessions(userId pkg.ULID) error {
+	return ss.sessionRepository.RevokeAllByUser(userId, time.Now())
+}
@@ -0,0 +1,20 @@
+package service
+
+import (
+	"github.com/clemilsonazevedo/blog/internal/repository"
+)
+
+type TagRepository = repository.TagRepository
+type TagService struct {
+	TagRepository *TagRepository
+}
+
+func NewTagService(tagRepository *repository.TagRepository) *TagService {
+	return &TagService{
+		TagRepository: tagRepository,
+	}
+}
+
+func (ts *TagService) GetAllTags() ([]repository.TagWithCount, error) {
+	return ts.TagRepository.GetAllWithPostCount()
+}
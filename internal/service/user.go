@@ -2,17 +2,22 @@ package service
 
 import (
 	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/mailer"
 	"github.com/clemilsonazevedo/blog/internal/repository"
 	"github.com/clemilsonazevedo/blog/pkg"
 )
 
 type UserService struct {
 	userRepository *repository.UserRepository
+	mailer         mailer.Mailer
+	config         UserConfig
 }
 
-func NewUserService(userRepository *repository.UserRepository) *UserService {
+func NewUserService(userRepository *repository.UserRepository, mailer mailer.Mailer, config UserConfig) *UserService {
 	return &UserService{
 		userRepository: userRepository,
+		mailer:         mailer,
+		config:         config,
 	}
 }
 
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/repository"
+	"github.com/clemilsonazevedo/blog/pkg"
+)
+
+var (
+	ErrInvalidRole        = errors.New("invalid role")
+	ErrInvalidSuspension  = errors.New("a suspension must end in the future")
+	ErrSelfAdministration = errors.New("admins cannot change their own role or status")
+	ErrAccountSuspended   = errors.New("this account is suspended")
+	ErrAccountBanned      = errors.New("this account is banned")
+)
+
+// CheckAccountStatus refuses banned users and users whose suspension did not
+// end yet, it is checked on every login and every authenticated request.
+func (us *UserService) CheckAccountStatus(user *entities.User) error {
+	if user.IsBanned() {
+		return ErrAccountBanned
+	}
+	if user.IsSuspended(time.Now()) {
+		return ErrAccountSuspended
+	}
+	return nil
+}
+
+func (us *UserService) SearchUsers(filter repository.UserFilter, page, limit int) ([]entities.User, int64, error) {
+	if filter.Role != "" && !filter.Role.IsValid() {
+		return nil, 0, ErrInvalidRole
+	}
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	offset := (page - 1) * limit
+	return us.userRepository.SearchUsers(filter, limit, offset, time.Now())
+}
+
+// ChangeRole returns the user with its new role and the previous one.
+func (us *UserService) ChangeRole(admin *entities.User, id pkg.ULID, role enums.Role) (*entities.User, enums.Role, error) {
+	if !role.IsValid() || role == enums.Anonymous {
+		return nil, "", ErrInvalidRole
+	}
+
+	user, err := us.administeredUser(admin, id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	previous := user.Role
+	if previous == role {
+		return user, previous, nil
+	}
+
+	if err := us.userRepository.UpdateRole(user.ID, role); err != nil {
+		return nil, "", err
+	}
+
+	user.Role = role
+	return user, previous, nil
+}
+
+func (us *UserService) SuspendUser(admin *entities.User, id pkg.ULID, until time.Time, reason string) (*entities.User, error) {
+	now := time.Now()
+	if !until.After(now) {
+		return nil, ErrInvalidSuspension
+	}
+
+	user, err := us.administeredUser(admin, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := us.userRepository.SetAccountStatus(user.ID, &until, user.BannedAt, reason, now); err != nil {
+		return nil, err
+	}
+
+	user.SuspendedUntil = &until
+	user.StatusReason = reason
+	return user, nil
+}
+
+func (us *UserService) BanUser(admin *entities.User, id pkg.ULID, reason string) (*entities.User, error) {
+	user, err := us.administeredUser(admin, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if err := us.userRepository.SetAccountStatus(user.ID, nil, &now, reason, now); err != nil {
+		return nil, err
+	}
+
+	user.SuspendedUntil = nil
+	user.BannedAt = &now
+	user.StatusReason = reason
+	return user, nil
+}
+
+// ReactivateUser lifts a suspension or a ban.
+func (us *UserService) ReactivateUser(admin *entities.User, id pkg.ULID) (*entities.User, error) {
+	user, err := us.administeredUser(admin, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := us.userRepository.SetAccountStatus(user.ID, nil, nil, "", time.Now()); err != nil {
+		return nil, err
+	}
+
+	user.SuspendedUntil = nil
+	user.BannedAt = nil
+	user.StatusReason = ""
+	return user, nil
+}
+
+// ForceLogout ends every session of the user, API tokens are kept.
+func (us *UserService) ForceLogout(id pkg.ULID) error {
+	user, err := us.userRepository.GetUserByID(id)
+	if err != nil {
+		return err
+	}
+
+	return us.userRepository.RevokeSessions(user.ID, time.Now())
+}
+
+// administeredUser loads the target of an admin action, admins cannot act on
+// themselves so the last admin never locks everybody out.
+func (us *UserService) administeredUser(admin *entities.User, id pkg.ULID) (*entities.User, error) {
+	if admin.ID == id {
+		return nil, ErrSelfAdministration
+	}
+	return us.userRepository.GetUserByID(id)
+}
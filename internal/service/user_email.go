@@ -0,0 +1,116 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/http/auth"
+	"github.com/clemilsonazevedo/blog/internal/mailer"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSameEmail               = errors.New("new email is the current one")
+	ErrEmailTaken              = errors.New("email already in use")
+	ErrInvalidEmailChangeToken = errors.New("invalid or expired email change token")
+)
+
+// RequestEmailChange sends a confirmation link to the new address, the email
+// of the account only changes once it is confirmed with ConfirmEmailChange.
+func (us *UserService) RequestEmailChange(user *entities.User, password string, email string) error {
+	if !auth.CheckPassword(user.Password, password) {
+		return ErrWrongPassword
+	}
+
+	if strings.EqualFold(user.Email, email) {
+		return ErrSameEmail
+	}
+
+	if err := us.checkEmailAvailable(email); err != nil {
+		return err
+	}
+
+	token, err := auth.GenerateEmailToken(auth.ChangeEmailPurpose, *user, email, us.config.VerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/profiles/email/confirm?token=%s", us.config.AppURL, url.QueryEscape(token))
+	err = us.mailer.Send(mailer.Message{
+		To:      email,
+		Subject: "Confirm your new email",
+		Body: fmt.Sprintf("Hi %s,\n\nConfirm this address to use it as the email of your account:\n%s\n\nThis link expires in %s.",
+			user.UserName, link, us.config.VerificationTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The current address is only warned, the change itself is not blocked
+	// if this email fails
+	_ = us.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Email change requested",
+		Body: fmt.Sprintf("Hi %s,\n\nSomeone asked to change the email of your account to %s. If it was not you, change your password.",
+			user.UserName, email),
+	})
+
+	return nil
+}
+
+// ConfirmEmailChange switches the email of the account to the address the
+// token was sent to and revokes every session. It returns the updated user
+// and the previous address.
+func (us *UserService) ConfirmEmailChange(token string) (*entities.User, string, error) {
+	claims, err := auth.ValidateEmailToken(auth.ChangeEmailPurpose, token)
+	if err != nil {
+		return nil, "", ErrInvalidEmailChangeToken
+	}
+
+	user, err := us.userRepository.GetUserByID(claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", ErrInvalidEmailChangeToken
+		}
+		return nil, "", err
+	}
+
+	// Already used, or the email changed again after the link was sent
+	if user.Email != claims.CurrentEmail {
+		return nil, "", ErrInvalidEmailChangeToken
+	}
+
+	if err := us.checkEmailAvailable(claims.Email); err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	changed, err := us.userRepository.ChangeEmail(user.ID, claims.CurrentEmail, claims.Email, now)
+	if err != nil {
+		return nil, "", err
+	}
+	if !changed {
+		return nil, "", ErrInvalidEmailChangeToken
+	}
+
+	previous := user.Email
+	user.Email = claims.Email
+	user.EmailVerifiedAt = &now
+	user.SessionsRevokedAt = &now
+	return user, previous, nil
+}
+
+func (us *UserService) checkEmailAvailable(email string) error {
+	existing, err := us.userRepository.GetUserByEmail(email)
+	if err != nil {
+		return err
+	}
+	if existing.Email != "" {
+		return ErrEmailTaken
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/http/auth"
+	"github.com/clemilsonazevedo/blog/internal/oidc"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"github.com/gosimple/slug"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrIdentityEmailMissing     = errors.New("the provider did not share an email address")
+	ErrIdentityEmailNotVerified = errors.New("the email of this account is used by another user and is not verified by the provider")
+)
+
+// IdentityLogin tells how the user of an external login was found.
+type IdentityLogin int
+
+const (
+	// IdentityExisting is a returning user of the provider
+	IdentityExisting IdentityLogin = iota
+	// IdentityLinked is an email/password account linked on this login
+	IdentityLinked
+	// IdentityCreated is a new user created from the provider account
+	IdentityCreated
+)
+
+// LoginWithIdentity returns the user of a verified provider account. The
+// account is linked to an existing user when the provider verified the email
+// of the user, otherwise a new reader is created.
+func (us *UserService) LoginWithIdentity(provider string, claims *oidc.Claims) (*entities.User, IdentityLogin, error) {
+	now := time.Now()
+
+	identity, err := us.userRepository.GetIdentity(claims.Issuer, claims.Subject)
+	if err == nil {
+		user, err := us.userRepository.GetUserByID(identity.UserID)
+		if err != nil {
+			return nil, IdentityExisting, err
+		}
+		if err := us.userRepository.TouchIdentity(identity.ID, claims.Email, now); err != nil {
+			return nil, IdentityExisting, err
+		}
+		return user, IdentityExisting, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, IdentityExisting, err
+	}
+
+	if claims.Email == "" {
+		return nil, IdentityExisting, ErrIdentityEmailMissing
+	}
+
+	identityId, err := pkg.NewULID()
+	if err != nil {
+		return nil, IdentityExisting, err
+	}
+	identity = &entities.UserIdentity{
+		ID:          identityId,
+		Provider:    provider,
+		Issuer:      claims.Issuer,
+		Subject:     claims.Subject,
+		Email:       claims.Email,
+		LastLoginAt: &now,
+	}
+
+	user, err := us.userRepository.GetUserByEmail(claims.Email)
+	if err != nil {
+		return nil, IdentityExisting, err
+	}
+
+	if user.Email != "" {
+		if !claims.EmailVerified {
+			return nil, IdentityExisting, ErrIdentityEmailNotVerified
+		}
+		if err := us.linkIdentity(user, identity, now); err != nil {
+			return nil, IdentityExisting, err
+		}
+		return user, IdentityLinked, nil
+	}
+
+	user, err = us.newIdentityUser(claims, now)
+	if err != nil {
+		return nil, IdentityExisting, err
+	}
+
+	identity.UserID = user.ID
+	if err := us.userRepository.CreateUserWithIdentity(user, identity); err != nil {
+		return nil, IdentityExisting, err
+	}
+	return user, IdentityCreated, nil
+}
+
+// linkIdentity links the provider account to a registered user. When the
+// user never verified its email, the account may have been registered by
+// someone else with this address: its password and sessions are dropped and
+// the email becomes verified.
+func (us *UserService) linkIdentity(user *entities.User, identity *entities.UserIdentity, now time.Time) error {
+	if !user.IsEmailVerified() {
+		passwordHash, err := unusablePassword()
+		if err != nil {
+			return err
+		}
+		if err := us.userRepository.UpdatePassword(user.ID, passwordHash, now); err != nil {
+			return err
+		}
+		if _, err := us.userRepository.MarkEmailVerified(user.ID, now); err != nil {
+			return err
+		}
+		user.Password = passwordHash
+		user.EmailVerifiedAt = &now
+	}
+
+	identity.UserID = user.ID
+	return us.userRepository.CreateIdentity(identity)
+}
+
+// newIdentityUser builds a reader without password, it can set one with the
+// forgot password flow.
+func (us *UserService) newIdentityUser(claims *oidc.Claims, now time.Time) (*entities.User, error) {
+	userId, err := pkg.NewULID()
+	if err != nil {
+		return nil, err
+	}
+
+	username, err := us.availableUserName(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := unusablePassword()
+	if err != nil {
+		return nil, err
+	}
+
+	user := &entities.User{
+		ID:       userId,
+		UserName: username,
+		Email:    claims.Email,
+		Password: passwordHash,
+		Role:     enums.Reader,
+	}
+	if claims.EmailVerified {
+		user.EmailVerifiedAt = &now
+	}
+	return user, nil
+}
+
+// availableUserName derives the username from the provider profile, with a
+// random suffix when it is already taken.
+func (us *UserService) availableUserName(claims *oidc.Claims) (string, error) {
+	base := claims.PreferredUsername
+	if base == "" {
+		base = claims.Name
+	}
+	if base == "" {
+		base, _, _ = strings.Cut(claims.Email, "@")
+	}
+	base = slug.Make(base)
+	if base == "" {
+		base = "user"
+	}
+
+	candidate := base
+	for range 5 {
+		exists, err := us.userRepository.UserNameExists(candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+
+		suffix, err := rand.Int(rand.Reader, big.NewInt(10000))
+		if err != nil {
+			return "", err
+		}
+		candidate = fmt.Sprintf("%s-%04d", base, suffix.Int64())
+	}
+
+	return "", fmt.Errorf("no available username for %q", base)
+}
+
+// unusablePassword hashes a random secret nobody knows, so the user can only
+// login with the provider until it resets its password.
+func unusablePassword() (string, error) {
+	secret, _, err := auth.NewOpaqueToken()
+	if err != nil {
+		return "", err
+	}
+	return auth.HashPassword(secret)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/http/auth"
+)
+
+var ErrInvalidCredentials = errors.New("email or password incorrect")
+
+// dummyPasswordHash is compared when the email is unknown, so the login takes
+// as long as with a registered email.
+var dummyPasswordHash = sync.OnceValue(func() string {
+	hash, _ := auth.HashPassword("not the password of anyone")
+	return hash
+})
+
+// CheckCredentials returns the user of the email when the password is right.
+// An unknown email and a wrong password give the same ErrInvalidCredentials.
+// A right password stored with a weaker hash than the configured one is
+// hashed again.
+func (us *UserService) CheckCredentials(email string, password string) (*entities.User, error) {
+	user, err := us.userRepository.GetUserByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Email == "" {
+		auth.CheckPassword(dummyPasswordHash(), password)
+		return nil, ErrInvalidCredentials
+	}
+
+	if !auth.CheckPassword(user.Password, password) {
+		return nil, ErrInvalidCredentials
+	}
+
+	us.upgradePasswordHash(user, password)
+	return user, nil
+}
+
+// upgradePasswordHash re-hashes the password with the configured algorithm
+// when the stored hash is weaker. The login goes on if it fails, the upgrade
+// is tried again next time.
+func (us *UserService) upgradePasswordHash(user *entities.User, password string) {
+	if !auth.PasswordNeedsRehash(user.Password) {
+		return
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		log.Printf("cannot rehash the password of user %s: %v", user.ID, err)
+		return
+	}
+
+	if err := us.userRepository.UpdatePasswordHash(user.ID, user.Password, hash); err != nil {
+		log.Printf("cannot rehash the password of user %s: %v", user.ID, err)
+		return
+	}
+	user.Password = hash
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/http/auth"
+	"github.com/clemilsonazevedo/blog/internal/mailer"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+	ErrWrongPassword     = errors.New("current password is incorrect")
+)
+
+// ForgotPassword emails a password reset link when the address belongs to a
+// user. Unknown addresses are silently ignored so the caller cannot tell
+// which emails are registered. The link is created and sent in the
+// background, so both cases answer in the same time.
+func (us *UserService) ForgotPassword(email string) error {
+	user, err := us.userRepository.GetUserByEmail(email)
+	if err != nil {
+		return err
+	}
+	if user.Email == "" {
+		return nil
+	}
+
+	go func() {
+		if err := us.sendPasswordReset(user); err != nil {
+			log.Printf("password forgot: cannot send the reset link to user %s: %v", user.ID, err)
+		}
+	}()
+	return nil
+}
+
+func (us *UserService) sendPasswordReset(user *entities.User) error {
+	token, tokenHash, err := auth.NewOpaqueToken()
+	if err != nil {
+		return err
+	}
+
+	tokenId, err := pkg.NewULID()
+	if err != nil {
+		return err
+	}
+
+	err = us.userRepository.CreatePasswordResetToken(&entities.PasswordResetToken{
+		ID:        tokenId,
+		UserID:    user.ID,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(us.config.PasswordResetTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/password/reset?token=%s", us.config.AppURL, url.QueryEscape(token))
+	return us.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nSomeone asked to reset the password of your account. Use the link below to choose a new one:\n%s\n\nThis link expires in %s and can be used once. If it was not you, ignore this email.",
+			user.UserName, link, us.config.PasswordResetTTL),
+	})
+}
+
+// ResetPassword sets a new password with a token sent by ForgotPassword and
+// logs the user out of every session.
+func (us *UserService) ResetPassword(token string, password string) error {
+	passwordHash, err := auth.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	_, err = us.userRepository.ResetPassword(auth.HashOpaqueToken(token), passwordHash, time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidResetToken
+	}
+	return err
+}
+
+// ChangePassword replaces the password of a logged user after checking the
+// current one. Every session issued before the change is revoked.
+func (us *UserService) ChangePassword(user *entities.User, current string, password string) error {
+	if !auth.CheckPassword(user.Password, current) {
+		return ErrWrongPassword
+	}
+
+	passwordHash, err := auth.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := us.userRepository.UpdatePassword(user.ID, passwordHash, now); err != nil {
+		return err
+	}
+
+	user.Password = passwordHash
+	user.SessionsRevokedAt = &now
+	return nil
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/domain/enums"
+	"github.com/clemilsonazevedo/blog/internal/http/auth"
+	"github.com/clemilsonazevedo/blog/pkg"
+	"gorm.io/gorm"
+)
+
+const recoveryCodeCount = 10
+
+var (
+	ErrTwoFactorEnabled     = errors.New("two factor authentication already enabled")
+	ErrTwoFactorNotEnrolled = errors.New("two factor authentication not enrolled")
+	ErrTwoFactorRequired    = errors.New("two factor authentication is required for this account")
+	ErrInvalidTwoFactorCode = errors.New("invalid two factor code")
+)
+
+// RequiresTwoFactor reports whether the role of the user forces two factor
+// authentication while it is not enabled yet.
+func (us *UserService) RequiresTwoFactor(user *entities.User) bool {
+	return us.twoFactorMandatory(user) && !user.IsTwoFactorEnabled()
+}
+
+// twoFactorMandatory reports whether the user must keep two factor
+// authentication. Admins follow the same rule as authors.
+func (us *UserService) twoFactorMandatory(user *entities.User) bool {
+	privileged := user.Role == enums.Author || user.Role == enums.Admin
+	return us.config.RequireAuthorTwoFactor && privileged
+}
+
+// EnrollTwoFactor creates a new TOTP secret for the user. It can be called
+// again to restart an enrollment that was not confirmed.
+func (us *UserService) EnrollTwoFactor(user *entities.User) (string, string, error) {
+	if user.IsTwoFactorEnabled() {
+		return "", "", ErrTwoFactorEnabled
+	}
+
+	secret, err := auth.NewTOTPSecret()
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := us.userRepository.SetTOTPSecret(user.ID, secret); err != nil {
+		return "", "", err
+	}
+
+	user.TOTPSecret = secret
+	return secret, auth.TOTPURI(us.config.TOTPIssuer, user.Email, secret), nil
+}
+
+// ConfirmTwoFactor enables two factor with the first code of the app and
+// returns the recovery codes, the only time they are shown.
+func (us *UserService) ConfirmTwoFactor(user *entities.User, code string) ([]string, error) {
+	if user.IsTwoFactorEnabled() {
+		return nil, ErrTwoFactorEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+
+	step, ok := auth.ValidateTOTP(user.TOTPSecret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, err := auth.NewRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes := make([]entities.RecoveryCode, len(codes))
+	for i, c := range codes {
+		codeId, err := pkg.NewULID()
+		if err != nil {
+			return nil, err
+		}
+		recoveryCodes[i] = entities.RecoveryCode{
+			ID:       codeId,
+			UserID:   user.ID,
+			CodeHash: auth.HashOpaqueToken(c),
+		}
+	}
+
+	now := time.Now()
+	if err := us.userRepository.EnableTwoFactor(user.ID, step, now, recoveryCodes); err != nil {
+		return nil, err
+	}
+
+	user.TwoFactorEnabledAt = &now
+	user.TOTPLastStep = step
+	return codes, nil
+}
+
+// DisableTwoFactor needs the password and a code (or a recovery code), a
+// stolen session alone cannot turn two factor off.
+func (us *UserService) DisableTwoFactor(user *entities.User, password string, code string) error {
+	if !user.IsTwoFactorEnabled() {
+		return ErrTwoFactorNotEnrolled
+	}
+
+	if us.twoFactorMandatory(user) {
+		return ErrTwoFactorRequired
+	}
+
+	if !auth.CheckPassword(user.Password, password) {
+		return ErrWrongPassword
+	}
+
+	if _, err := us.VerifyTwoFactor(user, code); err != nil {
+		return err
+	}
+
+	if err := us.userRepository.DisableTwoFactor(user.ID); err != nil {
+		return err
+	}
+
+	user.TwoFactorEnabledAt = nil
+	user.TOTPSecret = ""
+	return nil
+}
+
+// VerifyTwoFactor accepts a code of the app or a recovery code, each of
+// them only once. It reports whether a recovery code was spent.
+func (us *UserService) VerifyTwoFactor(user *entities.User, code string) (bool, error) {
+	now := time.Now()
+	if step, ok := auth.ValidateTOTP(user.TOTPSecret, code, now); ok {
+		used, err := us.userRepository.UseTOTPStep(user.ID, step)
+		if err != nil {
+			return false, err
+		}
+		if !used {
+			return false, ErrInvalidTwoFactorCode
+		}
+		return false, nil
+	}
+
+	used, err := us.userRepository.UseRecoveryCode(user.ID, auth.HashOpaqueToken(auth.NormalizeRecoveryCode(code)), now)
+	if err != nil {
+		return false, err
+	}
+	if !used {
+		return false, ErrInvalidTwoFactorCode
+	}
+	return true, nil
+}
+
+// StartTwoFactorLogin is the first step of the login of a user with two
+// factor: the password was right, the code is still missing.
+func (us *UserService) StartTwoFactorLogin(user *entities.User, device string) (string, time.Time, error) {
+	return auth.GenerateChallengeToken(user.ID, device, us.config.ChallengeTTL)
+}
+
+// ValidateTwoFactorChallenge returns the challenge of the second step of the
+// login and its user. The code is then checked with VerifyTwoFactor, once
+// the login guard allowed the attempt.
+func (us *UserService) ValidateTwoFactorChallenge(token string) (*auth.TwoFactorChallenge, *entities.User, error) {
+	challenge, err := auth.ValidateChallengeToken(token)
+	if err != nil {
+		return nil, nil, ErrInvalidTwoFactorCode
+	}
+
+	user, err := us.userRepository.GetUserByID(challenge.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, ErrInvalidTwoFactorCode
+		}
+		return nil, nil, err
+	}
+
+	if !user.IsTwoFactorEnabled() {
+		return nil, nil, ErrInvalidTwoFactorCode
+	}
+
+	return challenge, user, nil
+}
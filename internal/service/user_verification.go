@@ -0,0 +1,85 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/clemilsonazevedo/blog/internal/domain/entities"
+	"github.com/clemilsonazevedo/blog/internal/http/auth"
+	"github.com/clemilsonazevedo/blog/internal/mailer"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+	ErrEmailAlreadyVerified     = errors.New("email already verified")
+)
+
+type UserConfig struct {
+	// AppURL is used to build the links sent by email.
+	AppURL           string
+	VerificationTTL  time.Duration
+	PasswordResetTTL time.Duration
+
+	// TOTPIssuer is the name shown in the authenticator apps
+	TOTPIssuer             string
+	ChallengeTTL           time.Duration
+	RequireAuthorTwoFactor bool
+
+	PasswordPolicy PasswordPolicy
+}
+
+// SendVerificationEmail mails the user a link to confirm the account email.
+// Every link stays valid until it expires or one of them is used.
+func (us *UserService) SendVerificationEmail(user *entities.User) error {
+	if user.IsEmailVerified() {
+		return ErrEmailAlreadyVerified
+	}
+
+	token, err := auth.GenerateEmailToken(auth.VerifyEmailPurpose, *user, user.Email, us.config.VerificationTTL)
+	if err != nil {
+		return err
+	}
+
+	link := fmt.Sprintf("%s/verify-email?token=%s", us.config.AppURL, url.QueryEscape(token))
+	return us.mailer.Send(mailer.Message{
+		To:      user.Email,
+		Subject: "Confirm your email",
+		Body: fmt.Sprintf("Hi %s,\n\nConfirm your email to start commenting on the blog:\n%s\n\nThis link expires in %s.",
+			user.UserName, link, us.config.VerificationTTL),
+	})
+}
+
+func (us *UserService) VerifyEmail(token string) (*entities.User, error) {
+	claims, err := auth.ValidateEmailToken(auth.VerifyEmailPurpose, token)
+	if err != nil {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	user, err := us.userRepository.GetUserByID(claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidVerificationToken
+		}
+		return nil, err
+	}
+
+	// The address changed after the link was sent
+	if user.Email != claims.Email {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	now := time.Now()
+	verified, err := us.userRepository.MarkEmailVerified(user.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	if !verified {
+		return nil, ErrEmailAlreadyVerified
+	}
+
+	user.EmailVerifiedAt = &now
+	return user, nil
+}
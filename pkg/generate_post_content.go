@@ -1,13 +0,0 @@
-package pkg
-
-import "fmt"
-
-func GeneratePostContent(content string, hashtags []string) string {
-	postHashtags := []string{}
-	for _, val := range hashtags {
-		postHashtags = append(postHashtags, val)
-	}
-
-	postContent := fmt.Sprintf("%s\n\n%v", content, postHashtags)
-	return postContent
-}
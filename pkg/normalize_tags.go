@@ -0,0 +1,26 @@
+package pkg
+
+import (
+	"strings"
+
+	"github.com/gosimple/slug"
+)
+
+// NormalizeTags cleans user or AI provided hashtags ("#GoLang", " go ")
+// and drops empty values and duplicates that would share the same slug.
+func NormalizeTags(tags []string) []string {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, val := range tags {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(val), "#")))
+		key := slug.Make(name)
+		if key == "" || seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		normalized = append(normalized, name)
+	}
+
+	return normalized
+}
@@ -0,0 +1,144 @@
+package pkg
+
+import (
+	"fmt"
+	"strings"
+)
+
+const diffContext = 3
+
+type diffLine struct {
+	kind byte
+	text string
+}
+
+// UnifiedDiff returns a line based diff between from and to in the unified
+// format, or an empty string when both texts are equal.
+func UnifiedDiff(fromName, toName, from, to string) string {
+	lines := diffLines(splitLines(from), splitLines(to))
+
+	fromPos := make([]int, len(lines)+1)
+	toPos := make([]int, len(lines)+1)
+	changed := false
+	for i, line := range lines {
+		fromPos[i+1], toPos[i+1] = fromPos[i], toPos[i]
+		if line.kind != '+' {
+			fromPos[i+1]++
+		}
+		if line.kind != '-' {
+			toPos[i+1]++
+		}
+		if line.kind != ' ' {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return ""
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+
+	i := 0
+	for i < len(lines) {
+		for i < len(lines) && lines[i].kind == ' ' {
+			i++
+		}
+		if i == len(lines) {
+			break
+		}
+
+		start := max(i-diffContext, 0)
+		end := i
+		for {
+			for end < len(lines) && lines[end].kind != ' ' {
+				end++
+			}
+
+			equal := 0
+			for end+equal < len(lines) && lines[end+equal].kind == ' ' {
+				equal++
+			}
+
+			if end+equal == len(lines) || equal > 2*diffContext {
+				end = min(end+diffContext, len(lines))
+				break
+			}
+			end += equal
+		}
+
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n",
+			hunkRange(fromPos[start], fromPos[end]-fromPos[start]),
+			hunkRange(toPos[start], toPos[end]-toPos[start]),
+		)
+		for _, line := range lines[start:end] {
+			out.WriteByte(line.kind)
+			out.WriteString(line.text)
+			out.WriteByte('\n')
+		}
+
+		i = end
+	}
+
+	return out.String()
+}
+
+func hunkRange(before, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", before+1)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+func diffLines(from, to []string) []diffLine {
+	// lcs[i][j] holds the longest common subsequence of from[i:] and to[j:]
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, len(from)+len(to))
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			lines = append(lines, diffLine{' ', from[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{'-', from[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		lines = append(lines, diffLine{'-', from[i]})
+	}
+	for ; j < len(to); j++ {
+		lines = append(lines, diffLine{'+', to[j]})
+	}
+
+	return lines
+}